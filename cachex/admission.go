@@ -0,0 +1,181 @@
+package cachex
+
+import (
+	"sync"
+)
+
+// TinyLFU 准入过滤器：用 count-min sketch 估算键的访问频次，门卫布隆过滤器挡掉只出现一次的键
+// 新键只有在估算频次高于淘汰候选时才会写入，避免一次性扫描的键把热点数据挤出缓存
+type tinyLFU[K comparable] struct {
+	mu     sync.Mutex
+	sketch *countMinSketch
+	door   *doorkeeper
+	hasher func(K) uint64
+	// 累计记录次数达到 sample 时所有计数减半，让频次随时间衰减
+	added  int
+	sample int
+}
+
+func newTinyLFU[K comparable](capacity int) *tinyLFU[K] {
+	if capacity <= 0 {
+		capacity = 1024
+	}
+	sample := capacity * 10
+	return &tinyLFU[K]{
+		sketch: newCountMinSketch(capacity),
+		door:   newDoorkeeper(sample),
+		hasher: defaultHasher[K],
+		sample: sample,
+	}
+}
+
+// 记录一次访问，第一次出现只记入门卫
+func (t *tinyLFU[K]) record(key K) {
+	h := t.hasher(key)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.door.add(h) {
+		t.sketch.increment(h)
+	}
+	t.added++
+	if t.added >= t.sample {
+		t.sketch.reset()
+		t.door.clear()
+		t.added /= 2
+	}
+}
+
+func (t *tinyLFU[K]) estimate(h uint64) int {
+	n := t.sketch.estimate(h)
+	if t.door.contains(h) {
+		n++
+	}
+	return n
+}
+
+// 候选键的频次高于淘汰候选时才准入
+func (t *tinyLFU[K]) admit(candidate, victim K) bool {
+	ch, vh := t.hasher(candidate), t.hasher(victim)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.estimate(ch) > t.estimate(vh)
+}
+
+func (t *tinyLFU[K]) clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sketch.clear()
+	t.door.clear()
+	t.added = 0
+}
+
+const sketchDepth = 4
+
+// 4行的 count-min sketch，计数上限15
+type countMinSketch struct {
+	rows [sketchDepth][]uint8
+	mask uint64
+}
+
+func newCountMinSketch(capacity int) *countMinSketch {
+	// 宽度取容量的4倍，降低冷键与热点键冲突导致的高估
+	width := nextPowerOfTwo(capacity * 4)
+	s := &countMinSketch{mask: uint64(width - 1)}
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+	return s
+}
+
+// 每行使用不同的种子从同一个哈希值派生下标
+func (s *countMinSketch) index(h uint64, i int) uint64 {
+	return mix64(h+uint64(i)*0x9e3779b97f4a7c15) & s.mask
+}
+
+func (s *countMinSketch) increment(h uint64) {
+	for i := range s.rows {
+		idx := s.index(h, i)
+		if s.rows[i][idx] < 15 {
+			s.rows[i][idx]++
+		}
+	}
+}
+
+func (s *countMinSketch) estimate(h uint64) int {
+	n := uint8(15)
+	for i := range s.rows {
+		if v := s.rows[i][s.index(h, i)]; v < n {
+			n = v
+		}
+	}
+	return int(n)
+}
+
+func (s *countMinSketch) reset() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] >>= 1
+		}
+	}
+}
+
+func (s *countMinSketch) clear() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] = 0
+		}
+	}
+}
+
+// 门卫，简单的布隆过滤器
+type doorkeeper struct {
+	bits []uint64
+	mask uint64
+}
+
+// 门卫在每个衰减周期清空一次，按周期内的记录数确定大小
+func newDoorkeeper(sample int) *doorkeeper {
+	// 每条记录约占8位
+	n := nextPowerOfTwo(sample * 8)
+	return &doorkeeper{
+		bits: make([]uint64, (n+63)/64),
+		mask: uint64(n - 1),
+	}
+}
+
+// 写入哈希值，已存在时返回true
+func (d *doorkeeper) add(h uint64) bool {
+	exists := true
+	for i := 0; i < 2; i++ {
+		bit := mix64(h^uint64(i)) & d.mask
+		if d.bits[bit/64]&(1<<(bit%64)) == 0 {
+			exists = false
+			d.bits[bit/64] |= 1 << (bit % 64)
+		}
+	}
+	return exists
+}
+
+func (d *doorkeeper) contains(h uint64) bool {
+	for i := 0; i < 2; i++ {
+		bit := mix64(h^uint64(i)) & d.mask
+		if d.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *doorkeeper) clear() {
+	for i := range d.bits {
+		d.bits[i] = 0
+	}
+}
+
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
@@ -0,0 +1,121 @@
+package cachex
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// 测试：开启准入后一次性扫描的键不会挤出热点键
+func TestAdmissionScanResistant(t *testing.T) {
+	cache := NewLRUCache[int, int](CacheOption{MaxEntries: 100, Admission: true, EnableStats: true})
+	defer cache.Destroy()
+
+	// 热点键多次访问
+	for round := 0; round < 5; round++ {
+		for i := 0; i < 100; i++ {
+			if _, ok := cache.Get(i); !ok {
+				cache.Set(i, i)
+			}
+		}
+	}
+	// 大量一次性键，期间热点键仍有少量访问
+	for i := 1000; i < 11000; i++ {
+		if _, ok := cache.Get(i); !ok {
+			cache.Set(i, i)
+		}
+		if hot := i / 2 % 100; i%2 == 0 {
+			if _, ok := cache.Get(hot); !ok {
+				cache.Set(hot, hot)
+			}
+		}
+	}
+	hits := 0
+	for i := 0; i < 100; i++ {
+		if _, ok := cache.Get(i); ok {
+			hits++
+		}
+	}
+	if hits < 50 {
+		t.Fatalf("want most hot keys kept, got %d", hits)
+	}
+	if cache.Stats().Rejections == 0 {
+		t.Fatalf("want rejections recorded")
+	}
+}
+
+// 测试：被拒绝的值触发 EvictRejected 回调，未满时总是准入
+func TestAdmissionRejectEvent(t *testing.T) {
+	cache := NewLRUCache[string, int](CacheOption{MaxEntries: 2, Admission: true})
+	defer cache.Destroy()
+
+	var rejected []string
+	cache.OnEvict(func(key string, value int, reason EvictReason) {
+		if reason == EvictRejected {
+			rejected = append(rejected, key)
+		}
+	})
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Get("a")
+	cache.Get("b")
+	cache.Set("c", 3)
+	if _, ok := cache.Get("c"); ok {
+		t.Fatalf("want c rejected")
+	}
+	if len(rejected) != 1 || rejected[0] != "c" {
+		t.Fatalf("want [c], got %v", rejected)
+	}
+
+	// c 被多次写入后频次超过淘汰候选，可以准入
+	for i := 0; i < 5; i++ {
+		cache.Set("c", 3)
+	}
+	if v, ok := cache.Get("c"); !ok || v != 3 {
+		t.Fatalf("want c=3, got %v %v", v, ok)
+	}
+}
+
+// 热点键服从 zipf 分布，每访问 1000 次热点键穿插一段 2000 个一次性键的扫描
+func scanTrace(n int) []int {
+	r := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(r, 1.1, 1, 9999)
+	trace := make([]int, 0, n)
+	scan := 1 << 30
+	for len(trace) < n {
+		for i := 0; i < 1000; i++ {
+			trace = append(trace, int(zipf.Uint64()))
+		}
+		for i := 0; i < 2000; i++ {
+			trace = append(trace, scan)
+			scan++
+		}
+	}
+	return trace[:n]
+}
+
+func benchmarkHitRatio(b *testing.B, opts CacheOption) {
+	trace := scanTrace(200000)
+	b.ResetTimer()
+	var hits, total int
+	for i := 0; i < b.N; i++ {
+		cache := NewLRUCache[int, int](opts)
+		for _, key := range trace {
+			if _, ok := cache.Get(key); ok {
+				hits++
+			} else {
+				cache.Set(key, key)
+			}
+		}
+		total += len(trace)
+		cache.Destroy()
+	}
+	b.ReportMetric(float64(hits)/float64(total)*100, "hit%")
+}
+
+func BenchmarkHitRatioLRU(b *testing.B) {
+	benchmarkHitRatio(b, CacheOption{MaxEntries: 1000})
+}
+
+func BenchmarkHitRatioTinyLFU(b *testing.B) {
+	benchmarkHitRatio(b, CacheOption{MaxEntries: 1000, Admission: true})
+}
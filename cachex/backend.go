@@ -0,0 +1,95 @@
+package cachex
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// 二级缓存的存储后端，ttl小于等于0时永不过期
+type Backend interface {
+	Get(key string) ([]byte, bool, error)
+	Set(key string, value []byte, ttl time.Duration) error
+	Del(keys ...string) error
+}
+
+// 基于本地文件的后端，每个键一个文件，文件头8字节为到期时间
+type fileBackend struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+func NewFileBackend(dir string) (*fileBackend, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &fileBackend{dir: dir}, nil
+}
+
+// 文件名使用键的sha256，避免特殊字符和长度超出文件系统限制
+func (b *fileBackend) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(b.dir, hex.EncodeToString(sum[:]))
+}
+
+func (b *fileBackend) Get(key string) ([]byte, bool, error) {
+	b.mu.RLock()
+	data, err := os.ReadFile(b.path(key))
+	b.mu.RUnlock()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if len(data) < 8 {
+		return nil, false, errors.New("cachex: invalid backend file")
+	}
+	expire := int64(binary.BigEndian.Uint64(data))
+	if expire > 0 && time.Now().UnixNano() >= expire {
+		return nil, false, b.Del(key)
+	}
+	return data[8:], true, nil
+}
+
+func (b *fileBackend) Set(key string, value []byte, ttl time.Duration) error {
+	data := make([]byte, 8+len(value))
+	if ttl > 0 {
+		binary.BigEndian.PutUint64(data, uint64(time.Now().Add(ttl).UnixNano()))
+	}
+	copy(data[8:], value)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// 先写临时文件再重命名，避免读到写了一半的文件
+	tmp, err := os.CreateTemp(b.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Close()
+	} else {
+		tmp.Close()
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), b.path(key))
+}
+
+func (b *fileBackend) Del(keys ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, key := range keys {
+		if err := os.Remove(b.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
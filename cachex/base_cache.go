@@ -2,17 +2,47 @@ package cachex
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// 缓存关闭后的操作返回该错误
+var ErrClosed = errors.New("cachex: cache closed")
+
 // 一次性缓存，超过多久即会销毁
 
 type baseCache[K comparable, V any] struct {
 	mu     sync.RWMutex
 	cache  map[K]cacheItemWrapper[V]
 	opts   CacheOption
+	ctx    context.Context
 	cancel context.CancelFunc
+	loader loadGroup[K, V]
+	// 淘汰策略，为nil时容量不设上限
+	policy evictPolicy[K]
+	// 当前总权重及计算权重的函数，weigher 为nil时 Set 写入的权重为0
+	weight  int64
+	weigher func(K, V) int64
+	// TinyLFU 准入过滤器，未开启时为nil
+	admission *tinyLFU[K]
+	// 可过期的键按过期时间排列，定时清理时只需处理到期的部分
+	expires *expireQueue[K]
+	// 条目移除回调及待通知的事件
+	listeners []EvictListener[K, V]
+	events    []evictEvent[K, V]
+	// 统计数据，未开启时为nil
+	stats *cacheStats
+	// SetLoader 注册的加载函数，用于后台刷新
+	keyLoader KeyLoadFunc[K, V]
+	// 标签到键的索引
+	tags map[string]map[K]struct{}
+	// 生命周期，wg 跟踪所有后台协程，done 在关闭流程全部完成后关闭
+	life   sync.Mutex
+	closed atomic.Bool
+	wg     sync.WaitGroup
+	done   chan struct{}
 }
 
 type CacheOption struct {
@@ -20,60 +50,139 @@ type CacheOption struct {
 	DefaultKeyExpire time.Duration
 	CheckInterval    time.Duration
 	Destroy          func()
+	// 最大条目数，仅对带淘汰策略的缓存生效，小于等于0时不限制
+	MaxEntries int
+	// 最大总权重，仅对带淘汰策略的缓存生效，小于等于0时不限制
+	// 每个条目的权重由 SetWeigher 设置的函数计算，未设置时按键值占用的内存估算，SetCost 可以显式指定单个条目的权重
+	MaxWeight int64
+	// GetOrLoad 加载失败时错误的缓存时间，小于等于0时不缓存错误
+	ErrorExpire time.Duration
+	// 开启命中率、加载耗时等统计
+	EnableStats bool
+	// 分片缓存的分片数，小于等于0时按CPU数量决定
+	Shards int
+	// 软过期时间，写入超过该时间后读取仍返回旧值，同时在后台刷新一次
+	// 超过硬过期时间（DefaultKeyExpire 或 SetExpire 指定的时间）后读取才会阻塞加载
+	RefreshAfter time.Duration
+	// 开启 TinyLFU 准入，缓存满时新键的访问频次高于淘汰候选才会写入，仅对带淘汰策略的缓存生效
+	// 适合大量一次性键扫描的场景，避免热点数据被挤出缓存
+	Admission bool
 }
 
 func NewBaseCache[K comparable, V any](opts CacheOption) *baseCache[K, V] {
+	return newCache[K, V](context.Background(), opts, nil)
+}
+
+// 创建绑定到 ctx 的缓存，ctx 取消时缓存随之关闭
+func NewBaseCacheWithContext[K comparable, V any](ctx context.Context, opts CacheOption) *baseCache[K, V] {
+	return newCache[K, V](ctx, opts, nil)
+}
+
+func newCache[K comparable, V any](parent context.Context, opts CacheOption, policy evictPolicy[K]) *baseCache[K, V] {
 	cache := &baseCache[K, V]{
-		opts:  opts,
-		cache: make(map[K]cacheItemWrapper[V]),
+		opts:    opts,
+		cache:   make(map[K]cacheItemWrapper[V]),
+		policy:  policy,
+		expires: newExpireQueue[K](),
+		done:    make(chan struct{}),
+	}
+	if opts.EnableStats {
+		cache.stats = &cacheStats{}
+	}
+	if opts.MaxWeight > 0 {
+		cache.weigher = defaultWeigher[K, V]
+	}
+	if opts.Admission && policy != nil {
+		cache.admission = newTinyLFU[K](opts.MaxEntries)
+	}
+	// 在启动协程前创建ctx，避免创建后立即Destroy时cancel尚未赋值
+	if opts.Expire > 0 {
+		cache.ctx, cache.cancel = context.WithTimeout(parent, opts.Expire)
+	} else {
+		cache.ctx, cache.cancel = context.WithCancel(parent)
+	}
+	// 没有到期时间、清理间隔且父ctx不会取消时不需要后台协程，过期的键在写入时清理
+	if opts.Expire > 0 || opts.CheckInterval > 0 || parent.Done() != nil {
+		cache.goBackground(cache.start)
 	}
-	go cache.start()
 	return cache
 }
 
 func (c *baseCache[K, V]) start() {
-	if c.opts.Expire > 0 && c.opts.Destroy != nil {
-		defer c.opts.Destroy()
+	var tick <-chan time.Time
+	if c.opts.CheckInterval > 0 {
+		// 小于等于0的时候永不过期
+		ticker := time.NewTicker(c.opts.CheckInterval)
+		defer ticker.Stop()
+		tick = ticker.C
 	}
 
-	var ctx context.Context
-	if c.opts.Expire > 0 {
-		ctx, c.cancel = context.WithTimeout(context.Background(), c.opts.Expire)
-	} else {
-		ctx, c.cancel = context.WithCancel(context.Background())
+	for {
+		select {
+		case <-c.ctx.Done():
+			// Expire 到期或父ctx被取消，由后台完成关闭流程
+			if c.markClosed() {
+				go func() {
+					c.wg.Wait()
+					c.finish()
+				}()
+			}
+			return
+		case <-tick:
+			func() {
+				c.mu.Lock()
+				// 执行检查操作
+				defer c.unlockAndNotify()
+				c.removeExpired(time.Now())
+			}()
+		}
 	}
-	defer c.cancel()
+}
 
-	if c.opts.CheckInterval > 0 {
-		// 小于等于0的时候永不过期
-		go func() {
-			ticker := time.NewTicker(c.opts.CheckInterval)
-			defer ticker.Stop()
-
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					func() {
-						c.mu.Lock()
-						// 执行检查操作
-						defer c.mu.Unlock()
-						mp := make(map[K]cacheItemWrapper[V])
-						now := time.Now()
-						for key, item := range c.cache {
-							if item.canExpire && item.expire.After(now) {
-								mp[key] = item
-							}
-						}
-						c.cache = mp
-					}()
-				}
-			}
-		}()
+// 启动受生命周期管理的后台协程，缓存关闭后不再启动
+func (c *baseCache[K, V]) goBackground(fn func()) bool {
+	c.life.Lock()
+	defer c.life.Unlock()
+	if c.closed.Load() {
+		return false
+	}
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		fn()
+	}()
+	return true
+}
+
+func (c *baseCache[K, V]) markClosed() bool {
+	c.life.Lock()
+	defer c.life.Unlock()
+	if c.closed.Load() {
+		return false
+	}
+	c.closed.Store(true)
+	return true
+}
+
+func (c *baseCache[K, V]) finish() {
+	defer close(c.done)
+	if c.opts.Destroy != nil {
+		c.opts.Destroy()
 	}
+}
 
-	<-ctx.Done()
+// 关闭缓存，可以重复调用
+// 返回时所有后台协程都已退出，Destroy 回调已执行且只会执行一次
+// 关闭后读取返回未命中，写入不再生效，GetOrLoad 等返回 ErrClosed
+// 不要在 Destroy 回调中调用 Close
+func (c *baseCache[K, V]) Close() error {
+	if c.markClosed() {
+		c.cancel()
+		c.wg.Wait()
+		c.finish()
+	}
+	<-c.done
+	return nil
 }
 
 func (c *baseCache[K, V]) Set(key K, value V) {
@@ -82,21 +191,167 @@ func (c *baseCache[K, V]) Set(key K, value V) {
 
 func (c *baseCache[K, V]) SetExpire(key K, value V, expire time.Duration) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	defer c.unlockAndNotify()
+	if c.closed.Load() {
+		return
+	}
 	c.setExpire(key, value, expire)
 }
 
 func (c *baseCache[K, V]) setExpire(key K, value V, expire time.Duration) {
-	c.cache[key] = cacheItemWrapper[V]{
+	c.setItem(key, value, expire, c.weigh(key, value))
+}
+
+// 设置带成本的键值，成本即条目的权重，不再经过 SetWeigher 设置的函数计算
+// 总权重超过 MaxWeight 时会按淘汰策略移除旧的键，单个条目超过 MaxWeight 时直接拒绝
+func (c *baseCache[K, V]) SetCost(key K, value V, cost int64) {
+	c.mu.Lock()
+	defer c.unlockAndNotify()
+	if c.closed.Load() {
+		return
+	}
+	c.setItem(key, value, c.opts.DefaultKeyExpire, cost)
+}
+
+func (c *baseCache[K, V]) setItem(key K, value V, expire time.Duration, weight int64) {
+	now := time.Now()
+	if c.opts.CheckInterval <= 0 {
+		// 没有定时清理时在写入时顺带移除已过期的键，开销与过期的键数成正比
+		c.removeExpired(now)
+	}
+	if max := c.opts.MaxWeight; c.policy != nil && max > 0 && weight > max {
+		// 单个条目超过上限时直接拒绝，不为它淘汰其他的键，旧值也不再有效
+		c.remove(key, EvictReplaced)
+		c.stats.recordRemove(EvictRejected)
+		c.emit(key, value, EvictRejected)
+		return
+	}
+	old, exists := c.cache[key]
+	if !exists && !c.admit(key, weight) {
+		c.stats.recordRemove(EvictRejected)
+		c.emit(key, value, EvictRejected)
+		return
+	}
+	if exists {
+		c.weight -= old.weight
+		c.untag(key, old.tags)
+		if old.expired(now) {
+			c.emit(key, old.value, EvictExpired)
+		} else {
+			c.emit(key, old.value, EvictReplaced)
+		}
+	}
+	item := cacheItemWrapper[V]{
 		value:     value,
-		expire:    time.Now().Add(expire),
+		expire:    now.Add(expire),
 		canExpire: expire > 0,
+		weight:    weight,
+	}
+	if c.opts.RefreshAfter > 0 {
+		item.refresh = now.Add(c.opts.RefreshAfter)
+	}
+	c.cache[key] = item
+	c.weight += weight
+	if item.canExpire {
+		c.expires.set(key, item.expire)
+	} else if exists && old.canExpire {
+		c.expires.remove(key)
+	}
+	if c.policy == nil {
+		return
+	}
+	if exists {
+		c.policy.add(key)
+		c.evict()
+		return
+	}
+	// 新键先不参与淘汰，避免LFU下刚写入的键被立即淘汰
+	c.evict()
+	c.policy.add(key)
+	c.evict()
+}
+
+// 超出容量时按策略淘汰，每次淘汰都是O(1)
+func (c *baseCache[K, V]) evict() {
+	for c.overflow() {
+		key, ok := c.policy.victim()
+		if !ok {
+			return
+		}
+		c.remove(key, EvictEvicted)
+	}
+}
+
+// 缓存已满时由准入过滤器决定新键能否替换淘汰候选
+func (c *baseCache[K, V]) admit(key K, weight int64) bool {
+	if c.admission == nil {
+		return true
+	}
+	c.admission.record(key)
+	full := c.opts.MaxEntries > 0 && len(c.cache) >= c.opts.MaxEntries
+	if max := c.opts.MaxWeight; max > 0 && c.weight+weight > max {
+		full = true
+	}
+	if !full {
+		return true
+	}
+	victim, ok := c.policy.victim()
+	if !ok {
+		return true
+	}
+	return c.admission.admit(key, victim)
+}
+
+func (c *baseCache[K, V]) overflow() bool {
+	if c.opts.MaxEntries > 0 && len(c.cache) > c.opts.MaxEntries {
+		return true
+	}
+	max := c.opts.MaxWeight
+	return max > 0 && c.weight > max
+}
+
+func (c *baseCache[K, V]) weigh(key K, value V) int64 {
+	if c.weigher == nil {
+		return 0
+	}
+	return c.weigher(key, value)
+}
+
+func (c *baseCache[K, V]) remove(key K, reason EvictReason) {
+	item, ok := c.cache[key]
+	if !ok {
+		return
+	}
+	delete(c.cache, key)
+	c.untag(key, item.tags)
+	if item.canExpire {
+		c.expires.remove(key)
+	}
+	c.stats.recordRemove(reason)
+	c.emit(key, item.value, reason)
+	c.weight -= item.weight
+	if c.policy != nil {
+		c.policy.remove(key)
+	}
+}
+
+// 移除所有已到期的键
+func (c *baseCache[K, V]) removeExpired(now time.Time) {
+	for {
+		key, ok := c.expires.popExpired(now)
+		if !ok {
+			return
+		}
+		c.remove(key, EvictExpired)
 	}
 }
 
 func (c *baseCache[K, V]) SetMap(m map[K]V) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	defer c.unlockAndNotify()
+	if c.closed.Load() {
+		return
+	}
 	for key, value := range m {
 		key := key
 		value := value
@@ -105,71 +360,133 @@ func (c *baseCache[K, V]) SetMap(m map[K]V) {
 }
 
 func (c *baseCache[K, V]) Get(key K) (V, bool) {
+	if c.closed.Load() {
+		var zero V
+		return zero, false
+	}
+	c.mu.RLock()
+	item, ok := c.getItem(key)
+	loader := c.keyLoader
+	c.mu.RUnlock()
+	c.stats.recordGet(ok)
+	if ok && loader != nil && item.stale(time.Now()) {
+		c.refreshKey(key, loader)
+	}
+	return item.value, ok
+}
+
+func (c *baseCache[K, V]) refreshKey(key K, loader KeyLoadFunc[K, V]) {
+	c.refresh(key, func(ctx context.Context) (V, time.Duration, error) {
+		return loader(ctx, key)
+	})
+}
+
+// 不计入统计的读取
+func (c *baseCache[K, V]) lookup(key K) (V, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	return c.get(key)
 }
 
 func (c *baseCache[K, V]) Gets(keys ...K) []V {
+	if c.closed.Load() {
+		return []V{}
+	}
+	var stale []K
+	now := time.Now()
 	c.mu.RLock()
-	defer c.mu.RUnlock()
+	loader := c.keyLoader
 	values := make([]V, 0, len(keys))
 	for _, key := range keys {
-		value, ok := c.get(key)
+		item, ok := c.getItem(key)
+		c.stats.recordGet(ok)
 		if ok {
-			values = append(values, value)
+			values = append(values, item.value)
+			if loader != nil && item.stale(now) {
+				stale = append(stale, key)
+			}
 		}
 	}
+	c.mu.RUnlock()
+	for _, key := range stale {
+		c.refreshKey(key, loader)
+	}
 	return values
 }
 
 func (c *baseCache[K, V]) get(key K) (V, bool) {
+	item, ok := c.getItem(key)
+	return item.value, ok
+}
+
+func (c *baseCache[K, V]) getItem(key K) (cacheItemWrapper[V], bool) {
 	item, ok := c.cache[key]
-	if !ok {
-		return item.value, false
+	// 读取时检查过期，已过期但尚未被清理的键视为不存在
+	if !ok || item.expired(time.Now()) {
+		return cacheItemWrapper[V]{}, false
+	}
+	if c.policy != nil {
+		c.policy.access(key)
 	}
-	return item.value, true
+	// 未命中的键在随后写入时再计数，避免同一次请求被记录两次
+	if c.admission != nil {
+		c.admission.record(key)
+	}
+	return item, true
 }
 
 func (c *baseCache[K, V]) Del(key ...K) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	defer c.unlockAndNotify()
+	if c.closed.Load() {
+		return
+	}
 	for _, k := range key {
-		delete(c.cache, k)
+		c.remove(k, EvictDeleted)
 	}
+	c.loader.forget(key...)
 }
 
 func (c *baseCache[K, V]) Clear() {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	defer c.unlockAndNotify()
+	if c.closed.Load() {
+		return
+	}
+	for key, item := range c.cache {
+		c.emit(key, item.value, EvictCleared)
+	}
 	c.cache = make(map[K]cacheItemWrapper[V])
+	c.tags = nil
+	c.expires.clear()
+	c.weight = 0
+	if c.policy != nil {
+		c.policy.clear()
+	}
+	if c.admission != nil {
+		c.admission.clear()
+	}
+	c.loader.reset()
 }
 
-func (c *baseCache[K, V]) Destroy() {
-	c.mu.Lock()
-	c.cancel()
-	c.mu.Unlock()
+// 当前的条目数
+func (c *baseCache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.cache)
+}
 
-	// 解锁，防止出问题
-	if c.opts.Destroy != nil {
-		c.opts.Destroy()
-	}
+func (c *baseCache[K, V]) Destroy() {
+	c.Close()
 }
 
 func (c *baseCache[K, V]) GetOrSetFunc(key K, fn func() V) V {
-	value, ok := c.Get(key)
-	if !ok {
-		c.mu.Lock()
-		defer c.mu.Unlock()
-		if value, ok = c.get(key); ok {
-			return value
-		}
-		value = fn()
-		c.cache[key] = cacheItemWrapper[V]{
-			value:  value,
-			expire: time.Now().Add(c.opts.DefaultKeyExpire),
-		}
-		return value
+	value, err := c.GetOrLoad(key, func(ctx context.Context) (V, time.Duration, error) {
+		return fn(), 0, nil
+	})
+	if err != nil {
+		// 保持原有行为，fn中的panic继续向上抛出
+		panic(err)
 	}
 	return value
 }
@@ -0,0 +1,57 @@
+package cachex
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"reflect"
+
+	"github.com/llyb120/yoya/black"
+)
+
+// 快照及二级缓存使用的编解码方式
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	// 默认的编码方式，切片和map与 black.ToBytes 一致，其余类型直接使用gob
+	GobCodec  Codec = gobCodec{}
+	JSONCodec Codec = jsonCodec{}
+)
+
+type gobCodec struct{}
+
+func (gobCodec) Marshal(v any) ([]byte, error) {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Slice, reflect.Map:
+		return black.ToBytes(v)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func pickCodec(codec []Codec) Codec {
+	if len(codec) > 0 && codec[0] != nil {
+		return codec[0]
+	}
+	return GobCodec
+}
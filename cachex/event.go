@@ -0,0 +1,74 @@
+package cachex
+
+// 条目被移除的原因
+type EvictReason int
+
+const (
+	// 过期被清理
+	EvictExpired EvictReason = iota
+	// 超出容量被淘汰
+	EvictEvicted
+	// 被 Del 删除
+	EvictDeleted
+	// 被新的值覆盖
+	EvictReplaced
+	// 被 Clear 清空
+	EvictCleared
+	// 未通过准入过滤，新值没有写入缓存
+	EvictRejected
+)
+
+func (r EvictReason) String() string {
+	switch r {
+	case EvictExpired:
+		return "expired"
+	case EvictEvicted:
+		return "evicted"
+	case EvictDeleted:
+		return "deleted"
+	case EvictReplaced:
+		return "replaced"
+	case EvictCleared:
+		return "cleared"
+	case EvictRejected:
+		return "rejected"
+	}
+	return "unknown"
+}
+
+type EvictListener[K comparable, V any] func(key K, value V, reason EvictReason)
+
+type evictEvent[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictReason
+}
+
+// 注册条目移除时的回调，可用于释放值持有的资源或上报指标
+// 回调在释放缓存锁之后执行，可以在回调中访问缓存
+func (c *baseCache[K, V]) OnEvict(fn EvictListener[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listeners = append(c.listeners, fn)
+}
+
+// 需要在持有写锁时调用，记录待通知的事件
+func (c *baseCache[K, V]) emit(key K, value V, reason EvictReason) {
+	if len(c.listeners) == 0 {
+		return
+	}
+	c.events = append(c.events, evictEvent[K, V]{key: key, value: value, reason: reason})
+}
+
+// 释放写锁后再执行回调，避免回调中访问缓存造成死锁
+func (c *baseCache[K, V]) unlockAndNotify() {
+	events := c.events
+	listeners := c.listeners
+	c.events = nil
+	c.mu.Unlock()
+	for _, e := range events {
+		for _, fn := range listeners {
+			fn(e.key, e.value, e.reason)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package cachex
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type evictRecord struct {
+	key    string
+	value  int
+	reason EvictReason
+}
+
+func recordEvicts(c interface {
+	OnEvict(fn EvictListener[string, int])
+}) func() []evictRecord {
+	var mu sync.Mutex
+	var records []evictRecord
+	c.OnEvict(func(key string, value int, reason EvictReason) {
+		mu.Lock()
+		defer mu.Unlock()
+		records = append(records, evictRecord{key, value, reason})
+	})
+	return func() []evictRecord {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]evictRecord(nil), records...)
+	}
+}
+
+// 测试：删除、覆盖、清空、淘汰时触发回调
+func TestOnEvict(t *testing.T) {
+	cache := NewLRUCache[string, int](CacheOption{MaxEntries: 2})
+	defer cache.Destroy()
+	records := recordEvicts(cache)
+
+	cache.Set("a", 1)
+	cache.Set("a", 2)
+	cache.Set("b", 3)
+	cache.Set("c", 4)
+	cache.Del("b")
+	cache.Clear()
+
+	want := []evictRecord{
+		{"a", 1, EvictReplaced},
+		{"a", 2, EvictEvicted},
+		{"b", 3, EvictDeleted},
+		{"c", 4, EvictCleared},
+	}
+	got := records()
+	if len(got) != len(want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("want %v, got %v", want[i], got[i])
+		}
+	}
+}
+
+// 测试：过期清理时触发回调，且回调中可以访问缓存
+func TestOnEvictExpired(t *testing.T) {
+	cache := NewBaseCache[string, int](CacheOption{CheckInterval: 10 * time.Millisecond})
+	defer cache.Destroy()
+
+	done := make(chan evictRecord, 1)
+	cache.OnEvict(func(key string, value int, reason EvictReason) {
+		cache.Set("seen", value)
+		done <- evictRecord{key, value, reason}
+	})
+	cache.SetExpire("a", 1, 10*time.Millisecond)
+
+	select {
+	case r := <-done:
+		if r != (evictRecord{"a", 1, EvictExpired}) {
+			t.Fatalf("want expired a, got %v", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("want expire callback")
+	}
+	if v, ok := cache.Get("seen"); !ok || v != 1 {
+		t.Fatalf("want seen=1, got %v %v", v, ok)
+	}
+}
@@ -0,0 +1,87 @@
+package cachex
+
+import (
+	"container/heap"
+	"time"
+)
+
+// 按过期时间排序的小顶堆，清理时只处理已到期的键，开销与到期键的数量成正比，与缓存大小无关
+type expireQueue[K comparable] struct {
+	items []*expireEntry[K]
+	index map[K]*expireEntry[K]
+}
+
+type expireEntry[K comparable] struct {
+	key    K
+	expire time.Time
+	pos    int
+}
+
+func newExpireQueue[K comparable]() *expireQueue[K] {
+	return &expireQueue[K]{
+		index: make(map[K]*expireEntry[K]),
+	}
+}
+
+func (q *expireQueue[K]) Len() int { return len(q.items) }
+
+func (q *expireQueue[K]) Less(i, j int) bool {
+	return q.items[i].expire.Before(q.items[j].expire)
+}
+
+func (q *expireQueue[K]) Swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].pos = i
+	q.items[j].pos = j
+}
+
+func (q *expireQueue[K]) Push(x any) {
+	e := x.(*expireEntry[K])
+	e.pos = len(q.items)
+	q.items = append(q.items, e)
+}
+
+func (q *expireQueue[K]) Pop() any {
+	n := len(q.items)
+	e := q.items[n-1]
+	q.items[n-1] = nil
+	q.items = q.items[:n-1]
+	return e
+}
+
+// 新增或更新键的过期时间
+func (q *expireQueue[K]) set(key K, expire time.Time) {
+	if e, ok := q.index[key]; ok {
+		e.expire = expire
+		heap.Fix(q, e.pos)
+		return
+	}
+	e := &expireEntry[K]{key: key, expire: expire}
+	q.index[key] = e
+	heap.Push(q, e)
+}
+
+func (q *expireQueue[K]) remove(key K) {
+	e, ok := q.index[key]
+	if !ok {
+		return
+	}
+	heap.Remove(q, e.pos)
+	delete(q.index, key)
+}
+
+// 弹出一个在 now 之前到期的键
+func (q *expireQueue[K]) popExpired(now time.Time) (K, bool) {
+	if len(q.items) == 0 || q.items[0].expire.After(now) {
+		var zero K
+		return zero, false
+	}
+	e := heap.Pop(q).(*expireEntry[K])
+	delete(q.index, e.key)
+	return e.key, true
+}
+
+func (q *expireQueue[K]) clear() {
+	q.items = nil
+	q.index = make(map[K]*expireEntry[K])
+}
@@ -0,0 +1,70 @@
+package cachex
+
+import (
+	"testing"
+	"time"
+)
+
+// 测试：未开启定时清理时，读取也不会返回过期的值
+func TestLazyExpire(t *testing.T) {
+	cache := NewBaseCache[string, int](CacheOption{})
+	defer cache.Destroy()
+
+	cache.SetExpire("a", 1, 10*time.Millisecond)
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatalf("want a before expire")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := cache.Get("a"); ok {
+		t.Fatalf("want a expired on read")
+	}
+	if vals := cache.Gets("a"); len(vals) != 0 {
+		t.Fatalf("want no values, got %v", vals)
+	}
+}
+
+// 测试：定时清理只移除到期的键，不过期的键保留
+func TestExpireSweep(t *testing.T) {
+	cache := NewBaseCache[int, int](CacheOption{CheckInterval: 10 * time.Millisecond})
+	defer cache.Destroy()
+
+	for i := 0; i < 10; i++ {
+		cache.SetExpire(i, i, time.Duration(i+1)*5*time.Millisecond)
+	}
+	cache.SetExpire(100, 100, 0)
+	cache.SetExpire(101, 101, time.Hour)
+	// 重新设置为不过期后不再被清理
+	cache.SetExpire(0, 0, 0)
+
+	time.Sleep(100 * time.Millisecond)
+	if n := cache.Len(); n != 3 {
+		t.Fatalf("want 3 left, got %d", n)
+	}
+	for _, k := range []int{0, 100, 101} {
+		if _, ok := cache.Get(k); !ok {
+			t.Fatalf("want %d kept", k)
+		}
+	}
+}
+
+// 测试：堆按过期时间弹出
+func TestExpireQueue(t *testing.T) {
+	q := newExpireQueue[string]()
+	now := time.Now()
+	q.set("c", now.Add(3*time.Second))
+	q.set("a", now.Add(1*time.Second))
+	q.set("b", now.Add(2*time.Second))
+	q.set("c", now.Add(-time.Second))
+	q.remove("b")
+
+	want := []string{"c", "a"}
+	for _, w := range want {
+		key, ok := q.popExpired(now.Add(5 * time.Second))
+		if !ok || key != w {
+			t.Fatalf("want %s, got %s %v", w, key, ok)
+		}
+	}
+	if _, ok := q.popExpired(now.Add(5 * time.Second)); ok {
+		t.Fatalf("want empty queue")
+	}
+}
@@ -10,11 +10,35 @@ type Cache[K comparable, V any] interface {
 	Del(key ...K)
 	Clear()
 	Destroy()
+	Close() error
 	GetOrSetFunc(key K, fn func() V) V
+	GetOrLoad(key K, fn LoadFunc[V]) (V, error)
 }
 
 type cacheItemWrapper[T any] struct {
 	value     T
 	expire    time.Time
 	canExpire bool
+	weight    int64
+	// 软过期时间，为零值时不刷新
+	refresh time.Time
+	tags    []string
+}
+
+func (item cacheItemWrapper[T]) expired(now time.Time) bool {
+	return item.canExpire && !item.expire.After(now)
+}
+
+func (item cacheItemWrapper[T]) stale(now time.Time) bool {
+	return !item.refresh.IsZero() && !item.refresh.After(now)
+}
+
+// 淘汰策略，由缓存的写锁保护 add/remove/victim/clear，
+// access 在读锁下调用，实现需要自行保证并发安全
+type evictPolicy[K comparable] interface {
+	add(key K)
+	access(key K)
+	remove(key K)
+	victim() (K, bool)
+	clear()
 }
@@ -0,0 +1,114 @@
+package cachex
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// 最不经常使用淘汰，访问次数相同时移除最久未访问的键
+func NewLFUCache[K comparable, V any](opts CacheOption) *baseCache[K, V] {
+	return newCache[K, V](context.Background(), opts, newLFUPolicy[K]())
+}
+
+// 创建绑定到 ctx 的LFU缓存，ctx 取消时缓存随之关闭
+func NewLFUCacheWithContext[K comparable, V any](ctx context.Context, opts CacheOption) *baseCache[K, V] {
+	return newCache[K, V](ctx, opts, newLFUPolicy[K]())
+}
+
+// 频次桶按频次升序串成链表，每个桶内按访问先后排列，所有操作均为O(1)
+type lfuPolicy[K comparable] struct {
+	mu    sync.Mutex
+	freqs *list.List
+	items map[K]*lfuEntry[K]
+}
+
+type lfuBucket[K comparable] struct {
+	freq  int
+	items *list.List
+}
+
+type lfuEntry[K comparable] struct {
+	bucket *list.Element
+	elem   *list.Element
+}
+
+func newLFUPolicy[K comparable]() *lfuPolicy[K] {
+	return &lfuPolicy[K]{
+		freqs: list.New(),
+		items: make(map[K]*lfuEntry[K]),
+	}
+}
+
+func (p *lfuPolicy[K]) add(key K) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.items[key]; ok {
+		p.increment(key)
+		return
+	}
+	front := p.freqs.Front()
+	if front == nil || front.Value.(*lfuBucket[K]).freq != 1 {
+		front = p.freqs.PushFront(&lfuBucket[K]{freq: 1, items: list.New()})
+	}
+	p.items[key] = &lfuEntry[K]{
+		bucket: front,
+		elem:   front.Value.(*lfuBucket[K]).items.PushFront(key),
+	}
+}
+
+func (p *lfuPolicy[K]) access(key K) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.items[key]; ok {
+		p.increment(key)
+	}
+}
+
+func (p *lfuPolicy[K]) increment(key K) {
+	entry := p.items[key]
+	cur := entry.bucket.Value.(*lfuBucket[K])
+	next := entry.bucket.Next()
+	if next == nil || next.Value.(*lfuBucket[K]).freq != cur.freq+1 {
+		next = p.freqs.InsertAfter(&lfuBucket[K]{freq: cur.freq + 1, items: list.New()}, entry.bucket)
+	}
+	cur.items.Remove(entry.elem)
+	if cur.items.Len() == 0 {
+		p.freqs.Remove(entry.bucket)
+	}
+	entry.bucket = next
+	entry.elem = next.Value.(*lfuBucket[K]).items.PushFront(key)
+}
+
+func (p *lfuPolicy[K]) remove(key K) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry, ok := p.items[key]
+	if !ok {
+		return
+	}
+	bucket := entry.bucket.Value.(*lfuBucket[K])
+	bucket.items.Remove(entry.elem)
+	if bucket.items.Len() == 0 {
+		p.freqs.Remove(entry.bucket)
+	}
+	delete(p.items, key)
+}
+
+func (p *lfuPolicy[K]) victim() (K, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	front := p.freqs.Front()
+	if front == nil {
+		var zero K
+		return zero, false
+	}
+	return front.Value.(*lfuBucket[K]).items.Back().Value.(K), true
+}
+
+func (p *lfuPolicy[K]) clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.freqs.Init()
+	p.items = make(map[K]*lfuEntry[K])
+}
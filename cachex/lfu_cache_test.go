@@ -0,0 +1,48 @@
+package cachex
+
+import (
+	"testing"
+)
+
+// 测试：淘汰访问次数最少的键
+func TestLFUCacheEvict(t *testing.T) {
+	cache := NewLFUCache[string, int](CacheOption{MaxEntries: 2})
+	defer cache.Destroy()
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Get("a")
+	cache.Get("a")
+	cache.Get("b")
+	cache.Set("c", 3)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Fatalf("want b evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatalf("want a kept")
+	}
+	// 新写入的键不会被立即淘汰
+	if _, ok := cache.Get("c"); !ok {
+		t.Fatalf("want c kept")
+	}
+}
+
+// 测试：频次相同时淘汰最久未访问的键
+func TestLFUCacheTie(t *testing.T) {
+	cache := NewLFUCache[int, int](CacheOption{MaxEntries: 3})
+	defer cache.Destroy()
+
+	for i := 0; i < 3; i++ {
+		cache.Set(i, i)
+	}
+	cache.Del(1)
+	cache.Set(3, 3)
+	cache.Set(4, 4)
+	if _, ok := cache.Get(0); ok {
+		t.Fatalf("want 0 evicted")
+	}
+	if cache.Len() != 3 {
+		t.Fatalf("want len 3, got %d", cache.Len())
+	}
+}
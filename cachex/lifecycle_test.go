@@ -0,0 +1,100 @@
+package cachex
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 测试：Close 可以重复调用，Destroy 回调只执行一次
+func TestCloseIdempotent(t *testing.T) {
+	var destroyed int32
+	cache := NewBaseCache[string, int](CacheOption{
+		Expire:        20 * time.Millisecond,
+		CheckInterval: time.Millisecond,
+		Destroy: func() {
+			atomic.AddInt32(&destroyed, 1)
+		},
+	})
+	time.Sleep(40 * time.Millisecond)
+	if err := cache.Close(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+	cache.Close()
+	cache.Destroy()
+	if n := atomic.LoadInt32(&destroyed); n != 1 {
+		t.Fatalf("want destroy once, got %d", n)
+	}
+}
+
+// 测试：父ctx取消时缓存关闭，关闭后的操作返回 ErrClosed
+func TestCloseWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cache := NewBaseCacheWithContext[string, int](ctx, CacheOption{CheckInterval: time.Millisecond})
+	cache.Set("a", 1)
+	cancel()
+	cache.Close()
+
+	cache.Set("b", 2)
+	if _, ok := cache.Get("a"); ok {
+		t.Fatalf("want miss after close")
+	}
+	_, err := cache.GetOrLoad("c", func(ctx context.Context) (int, time.Duration, error) {
+		t.Fatalf("want loader not called after close")
+		return 0, 0, nil
+	})
+	if err != ErrClosed {
+		t.Fatalf("want ErrClosed, got %v", err)
+	}
+}
+
+// 测试：有界缓存和分片缓存同样可以绑定到父ctx
+func TestCloseWithContextBounded(t *testing.T) {
+	var destroyed int32
+	opts := CacheOption{MaxEntries: 10, Destroy: func() { atomic.AddInt32(&destroyed, 1) }}
+	for name, create := range map[string]func(ctx context.Context) Cache[string, int]{
+		"lru":     func(ctx context.Context) Cache[string, int] { return NewLRUCacheWithContext[string, int](ctx, opts) },
+		"lfu":     func(ctx context.Context) Cache[string, int] { return NewLFUCacheWithContext[string, int](ctx, opts) },
+		"sharded": func(ctx context.Context) Cache[string, int] { return NewShardedCacheWithContext[string, int](ctx, opts) },
+	} {
+		atomic.StoreInt32(&destroyed, 0)
+		ctx, cancel := context.WithCancel(context.Background())
+		cache := create(ctx)
+		cache.Set("a", 1)
+		cancel()
+		// 父ctx取消后缓存在后台关闭，这里等待关闭完成
+		deadline := time.Now().Add(time.Second)
+		for atomic.LoadInt32(&destroyed) == 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if n := atomic.LoadInt32(&destroyed); n != 1 {
+			t.Fatalf("%s: want destroyed once, got %d", name, n)
+		}
+		if _, err := cache.GetOrLoad("b", func(ctx context.Context) (int, time.Duration, error) {
+			return 2, 0, nil
+		}); err != ErrClosed {
+			t.Fatalf("%s: want ErrClosed, got %v", name, err)
+		}
+		cache.Close()
+	}
+}
+
+// 测试：Close 返回时后台协程已全部退出
+func TestCloseStopsGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for i := 0; i < 50; i++ {
+		cache := NewBaseCache[string, int](CacheOption{CheckInterval: time.Millisecond, RefreshAfter: time.Nanosecond})
+		cache.SetLoader(func(ctx context.Context, key string) (int, time.Duration, error) {
+			<-ctx.Done()
+			return 0, 0, ctx.Err()
+		})
+		cache.Set("a", 1)
+		cache.Get("a")
+		cache.Close()
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("want no leaked goroutines, before %d after %d", before, after)
+	}
+}
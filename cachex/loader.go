@@ -0,0 +1,236 @@
+package cachex
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/llyb120/yoya/errx"
+)
+
+// 加载函数，返回值、该键的过期时间以及错误
+// 过期时间为0时使用 DefaultKeyExpire，小于0时永不过期
+type LoadFunc[V any] func(ctx context.Context) (V, time.Duration, error)
+
+// 按键加载的函数，用于 SetLoader 注册的后台刷新
+type KeyLoadFunc[K comparable, V any] func(ctx context.Context, key K) (V, time.Duration, error)
+
+type loadCall[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	err error
+	// 加载期间键被 Del 或 Clear，结果不再写入缓存，由 loadGroup.mu 保护
+	stale bool
+}
+
+type loadError struct {
+	err    error
+	expire time.Time
+}
+
+// 按键合并并发加载，同一个键同时只会有一个加载函数在执行
+type loadGroup[K comparable, V any] struct {
+	mu     sync.Mutex
+	calls  map[K]*loadCall[V]
+	errors map[K]loadError
+	// 正在后台刷新的键
+	refreshing map[K]*loadCall[V]
+}
+
+// 获取键对应的值，不存在时调用 fn 加载
+// 加载过程不持有缓存锁，同一个键的并发请求只会触发一次加载，错误不会被写入缓存
+// 开启 RefreshAfter 时，超过软过期时间的值会直接返回，同时在后台用 fn 刷新
+func (c *baseCache[K, V]) GetOrLoad(key K, fn LoadFunc[V]) (V, error) {
+	if c.closed.Load() {
+		var zero V
+		return zero, ErrClosed
+	}
+	c.mu.RLock()
+	item, ok := c.getItem(key)
+	c.mu.RUnlock()
+	c.stats.recordGet(ok)
+	if ok {
+		if item.stale(time.Now()) {
+			c.refresh(key, fn)
+		}
+		return item.value, nil
+	}
+	g := &c.loader
+	g.mu.Lock()
+	g.init()
+	if le, ok := g.errors[key]; ok {
+		if time.Now().Before(le.expire) {
+			g.mu.Unlock()
+			var zero V
+			return zero, le.err
+		}
+		delete(g.errors, key)
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.val, call.err
+	}
+	call := &loadCall[V]{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	c.doLoad(key, call, fn)
+	return call.val, call.err
+}
+
+func (c *baseCache[K, V]) doLoad(key K, call *loadCall[V], fn LoadFunc[V]) {
+	g := &c.loader
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		if call.err != nil && c.opts.ErrorExpire > 0 && !call.stale {
+			g.errors[key] = loadError{err: call.err, expire: time.Now().Add(c.opts.ErrorExpire)}
+		}
+		g.mu.Unlock()
+		call.wg.Done()
+	}()
+
+	// 双重检查，加载期间可能已经被其他途径写入
+	if value, ok := c.lookup(key); ok {
+		call.val = value
+		return
+	}
+	var expire time.Duration
+	call.val, expire, call.err = c.runLoad(fn)
+	if call.err == nil {
+		c.storeLoaded(key, call, call.val, expire)
+	}
+}
+
+// 写入加载的结果，加载期间键被 Del 或 Clear 时丢弃，避免把旧值写回缓存
+func (c *baseCache[K, V]) storeLoaded(key K, call *loadCall[V], value V, expire time.Duration) {
+	c.mu.Lock()
+	defer c.unlockAndNotify()
+	if c.closed.Load() {
+		return
+	}
+	c.loader.mu.Lock()
+	stale := call.stale
+	c.loader.mu.Unlock()
+	if !stale {
+		c.setExpire(key, value, expire)
+	}
+}
+
+// 执行加载函数并记录统计，返回值中的过期时间已按 DefaultKeyExpire 转换
+func (c *baseCache[K, V]) runLoad(fn LoadFunc[V]) (value V, expire time.Duration, err error) {
+	start := time.Now()
+	err = errx.Try(func() (err error) {
+		value, expire, err = fn(c.ctx)
+		return err
+	})
+	c.stats.recordLoad(time.Since(start), err)
+	if err != nil {
+		var zero V
+		return zero, 0, err
+	}
+	if expire == 0 {
+		expire = c.opts.DefaultKeyExpire
+	} else if expire < 0 {
+		expire = 0
+	}
+	return value, expire, nil
+}
+
+// 注册按键加载的函数，开启 RefreshAfter 时 Get 读到需要刷新的值会用它在后台刷新
+func (c *baseCache[K, V]) SetLoader(fn KeyLoadFunc[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keyLoader = fn
+}
+
+// 在后台刷新键，同一个键同时只会有一个刷新任务，刷新失败时继续使用旧值直到硬过期
+func (c *baseCache[K, V]) refresh(key K, fn LoadFunc[V]) {
+	if fn == nil {
+		return
+	}
+	g := &c.loader
+	g.mu.Lock()
+	g.init()
+	if _, ok := g.refreshing[key]; ok {
+		g.mu.Unlock()
+		return
+	}
+	call := &loadCall[V]{}
+	g.refreshing[key] = call
+	g.mu.Unlock()
+
+	done := func() {
+		g.mu.Lock()
+		delete(g.refreshing, key)
+		g.mu.Unlock()
+	}
+	ok := c.goBackground(func() {
+		defer done()
+		value, expire, err := c.runLoad(fn)
+		if err == nil {
+			c.storeLoaded(key, call, value, expire)
+		}
+	})
+	if !ok {
+		done()
+	}
+}
+
+func (g *loadGroup[K, V]) init() {
+	if g.calls == nil {
+		g.calls = make(map[K]*loadCall[V])
+		g.errors = make(map[K]loadError)
+		g.refreshing = make(map[K]*loadCall[V])
+	}
+}
+
+// 清除键上缓存的加载错误，正在进行的加载和刷新结果不再写入缓存
+func (g *loadGroup[K, V]) forget(keys ...K) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, key := range keys {
+		delete(g.errors, key)
+		if call, ok := g.calls[key]; ok {
+			call.stale = true
+		}
+		if call, ok := g.refreshing[key]; ok {
+			call.stale = true
+		}
+	}
+}
+
+// 与 forget 相同，作用于所有符合 match 的键
+func (g *loadGroup[K, V]) forgetWhere(match func(key K) bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for key := range g.errors {
+		if match(key) {
+			delete(g.errors, key)
+		}
+	}
+	for key, call := range g.calls {
+		if match(key) {
+			call.stale = true
+		}
+	}
+	for key, call := range g.refreshing {
+		if match(key) {
+			call.stale = true
+		}
+	}
+}
+
+func (g *loadGroup[K, V]) reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.errors = make(map[K]loadError)
+	for _, call := range g.calls {
+		call.stale = true
+	}
+	for _, call := range g.refreshing {
+		call.stale = true
+	}
+}
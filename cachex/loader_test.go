@@ -0,0 +1,166 @@
+package cachex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 测试：并发加载同一个键只执行一次
+func TestGetOrLoadDedup(t *testing.T) {
+	cache := NewBaseCache[string, int](CacheOption{})
+	defer cache.Destroy()
+
+	var calls int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := cache.GetOrLoad("k", func(ctx context.Context) (int, time.Duration, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(50 * time.Millisecond)
+				return 42, 0, nil
+			})
+			if err != nil || v != 42 {
+				t.Errorf("want 42, got %v %v", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Fatalf("want 1 load, got %d", calls)
+	}
+}
+
+// 测试：慢加载不阻塞其他键的读写
+func TestGetOrLoadNotBlocking(t *testing.T) {
+	cache := NewBaseCache[string, int](CacheOption{})
+	defer cache.Destroy()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go cache.GetOrLoad("slow", func(ctx context.Context) (int, time.Duration, error) {
+		close(started)
+		<-release
+		return 1, 0, nil
+	})
+	<-started
+	cache.Set("other", 2)
+	if v, ok := cache.Get("other"); !ok || v != 2 {
+		t.Fatalf("want other=2, got %v %v", v, ok)
+	}
+	close(release)
+}
+
+// 测试：错误不缓存，或按 ErrorExpire 短暂缓存
+func TestGetOrLoadError(t *testing.T) {
+	errLoad := errors.New("load failed")
+	cache := NewBaseCache[string, int](CacheOption{})
+	defer cache.Destroy()
+
+	var calls int
+	load := func(ctx context.Context) (int, time.Duration, error) {
+		calls++
+		return 0, 0, errLoad
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := cache.GetOrLoad("k", load); !errors.Is(err, errLoad) {
+			t.Fatalf("want errLoad, got %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("want 2 loads, got %d", calls)
+	}
+	if _, ok := cache.Get("k"); ok {
+		t.Fatalf("want error not cached")
+	}
+
+	negative := NewBaseCache[string, int](CacheOption{ErrorExpire: time.Minute})
+	defer negative.Destroy()
+	calls = 0
+	negative.GetOrLoad("k", load)
+	negative.GetOrLoad("k", load)
+	if calls != 1 {
+		t.Fatalf("want 1 load with negative cache, got %d", calls)
+	}
+	negative.Del("k")
+	negative.GetOrLoad("k", load)
+	if calls != 2 {
+		t.Fatalf("want reload after del, got %d", calls)
+	}
+}
+
+// 测试：使用加载函数返回的过期时间
+func TestGetOrLoadExpire(t *testing.T) {
+	cache := NewBaseCache[string, int](CacheOption{CheckInterval: 10 * time.Millisecond})
+	defer cache.Destroy()
+
+	cache.GetOrLoad("k", func(ctx context.Context) (int, time.Duration, error) {
+		return 1, 20 * time.Millisecond, nil
+	})
+	if _, ok := cache.Get("k"); !ok {
+		t.Fatalf("want k loaded")
+	}
+	time.Sleep(60 * time.Millisecond)
+	if _, ok := cache.Get("k"); ok {
+		t.Fatalf("want k expired")
+	}
+}
+
+// 测试：加载函数panic转换为错误
+func TestGetOrLoadPanic(t *testing.T) {
+	cache := NewBaseCache[string, int](CacheOption{})
+	defer cache.Destroy()
+
+	_, err := cache.GetOrLoad("k", func(ctx context.Context) (int, time.Duration, error) {
+		panic("boom")
+	})
+	if err == nil {
+		t.Fatalf("want panic error")
+	}
+}
+
+// 测试：加载期间键被删除或清空时，加载结果不写回缓存
+func TestGetOrLoadInvalidatedDuringLoad(t *testing.T) {
+	cache := NewBaseCache[string, int](CacheOption{})
+	defer cache.Destroy()
+
+	for _, invalidate := range []func(){
+		func() { cache.Del("k") },
+		cache.Clear,
+	} {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		done := make(chan int)
+		go func() {
+			v, _ := cache.GetOrLoad("k", func(ctx context.Context) (int, time.Duration, error) {
+				close(started)
+				<-release
+				return 1, 0, nil
+			})
+			done <- v
+		}()
+		<-started
+		invalidate()
+		close(release)
+		// 调用方仍然得到加载的值
+		if v := <-done; v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+		if v, ok := cache.Get("k"); ok {
+			t.Fatalf("want miss, got %d", v)
+		}
+	}
+
+	// 之后的加载正常写入
+	cache.GetOrLoad("k", func(ctx context.Context) (int, time.Duration, error) {
+		return 2, 0, nil
+	})
+	if v, ok := cache.Get("k"); !ok || v != 2 {
+		t.Fatalf("want 2, got %v %v", v, ok)
+	}
+}
@@ -0,0 +1,75 @@
+package cachex
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// 最近最少使用淘汰，超过 MaxEntries / MaxWeight 时移除最久未访问的键
+func NewLRUCache[K comparable, V any](opts CacheOption) *baseCache[K, V] {
+	return newCache[K, V](context.Background(), opts, newLRUPolicy[K]())
+}
+
+// 创建绑定到 ctx 的LRU缓存，ctx 取消时缓存随之关闭
+func NewLRUCacheWithContext[K comparable, V any](ctx context.Context, opts CacheOption) *baseCache[K, V] {
+	return newCache[K, V](ctx, opts, newLRUPolicy[K]())
+}
+
+type lruPolicy[K comparable] struct {
+	mu    sync.Mutex
+	ll    *list.List
+	items map[K]*list.Element
+}
+
+func newLRUPolicy[K comparable]() *lruPolicy[K] {
+	return &lruPolicy[K]{
+		ll:    list.New(),
+		items: make(map[K]*list.Element),
+	}
+}
+
+func (p *lruPolicy[K]) add(key K) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e, ok := p.items[key]; ok {
+		p.ll.MoveToFront(e)
+		return
+	}
+	p.items[key] = p.ll.PushFront(key)
+}
+
+func (p *lruPolicy[K]) access(key K) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e, ok := p.items[key]; ok {
+		p.ll.MoveToFront(e)
+	}
+}
+
+func (p *lruPolicy[K]) remove(key K) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e, ok := p.items[key]; ok {
+		p.ll.Remove(e)
+		delete(p.items, key)
+	}
+}
+
+func (p *lruPolicy[K]) victim() (K, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e := p.ll.Back()
+	if e == nil {
+		var zero K
+		return zero, false
+	}
+	return e.Value.(K), true
+}
+
+func (p *lruPolicy[K]) clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ll.Init()
+	p.items = make(map[K]*list.Element)
+}
@@ -0,0 +1,109 @@
+package cachex
+
+import (
+	"testing"
+)
+
+// 测试：超过最大条目数时淘汰最久未访问的键
+func TestLRUCacheEvict(t *testing.T) {
+	cache := NewLRUCache[string, int](CacheOption{MaxEntries: 2})
+	defer cache.Destroy()
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	// 访问a，使b成为最久未访问
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatalf("want a in cache")
+	}
+	cache.Set("c", 3)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Fatalf("want b evicted")
+	}
+	if v, ok := cache.Get("a"); !ok || v != 1 {
+		t.Fatalf("want a=1, got %v %v", v, ok)
+	}
+	if v, ok := cache.Get("c"); !ok || v != 3 {
+		t.Fatalf("want c=3, got %v %v", v, ok)
+	}
+	if cache.Len() != 2 {
+		t.Fatalf("want len 2, got %d", cache.Len())
+	}
+}
+
+// 测试：按成本淘汰
+func TestLRUCacheCost(t *testing.T) {
+	cache := NewLRUCache[string, string](CacheOption{MaxWeight: 10})
+	defer cache.Destroy()
+
+	cache.SetCost("a", "a", 4)
+	cache.SetCost("b", "b", 4)
+	cache.SetCost("c", "c", 4)
+	if _, ok := cache.Get("a"); ok {
+		t.Fatalf("want a evicted")
+	}
+	if cache.Len() != 2 {
+		t.Fatalf("want len 2, got %d", cache.Len())
+	}
+
+	// 单个超过上限的键直接拒绝，不影响已有的键
+	var rejected []string
+	cache.OnEvict(func(key string, value string, reason EvictReason) {
+		if reason == EvictRejected {
+			rejected = append(rejected, key)
+		}
+	})
+	cache.SetCost("big", "big", 11)
+	if _, ok := cache.Get("big"); ok {
+		t.Fatalf("want big rejected")
+	}
+	if cache.Len() != 2 {
+		t.Fatalf("want len 2, got %d", cache.Len())
+	}
+	for _, key := range []string{"b", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Fatalf("want %s kept", key)
+		}
+	}
+	if len(rejected) != 1 || rejected[0] != "big" {
+		t.Fatalf("want big rejected event, got %v", rejected)
+	}
+}
+
+// 测试：删除和清空后策略状态保持一致
+func TestLRUCacheDelClear(t *testing.T) {
+	cache := NewLRUCache[int, int](CacheOption{MaxEntries: 3})
+	defer cache.Destroy()
+
+	for i := 0; i < 3; i++ {
+		cache.Set(i, i)
+	}
+	cache.Del(0)
+	cache.Set(3, 3)
+	if cache.Len() != 3 {
+		t.Fatalf("want len 3, got %d", cache.Len())
+	}
+	if _, ok := cache.Get(1); !ok {
+		t.Fatalf("want 1 kept after del freed a slot")
+	}
+
+	cache.Clear()
+	for i := 10; i < 14; i++ {
+		cache.Set(i, i)
+	}
+	if cache.Len() != 3 {
+		t.Fatalf("want len 3, got %d", cache.Len())
+	}
+	if _, ok := cache.Get(10); ok {
+		t.Fatalf("want 10 evicted")
+	}
+}
+
+func BenchmarkLRUCacheSet(b *testing.B) {
+	cache := NewLRUCache[int, int](CacheOption{MaxEntries: 1024})
+	defer cache.Destroy()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.Set(i, i)
+	}
+}
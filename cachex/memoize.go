@@ -0,0 +1,209 @@
+package cachex
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+type MemoOption struct {
+	// 过期时间使用 DefaultKeyExpire，设置 MaxEntries / MaxWeight 时使用LRU淘汰
+	// 包装后的函数没有重建缓存的办法，不能设置 Expire 和 Destroy
+	CacheOption
+	// 自定义缓存键，参数为函数的全部入参，返回值必须是可比较的类型
+	// 不设置时可比较的参数直接作为键，其余参数按内容计算摘要后作为键
+	KeyFunc func(args ...any) any
+}
+
+func newMemoCache[R any](opts MemoOption) Cache[any, R] {
+	if opts.Expire > 0 || opts.Destroy != nil {
+		panic("cachex: Memoize does not support Expire or Destroy, use DefaultKeyExpire")
+	}
+	if opts.bounded() {
+		return NewLRUCache[any, R](opts.CacheOption)
+	}
+	return NewBaseCache[any, R](opts.CacheOption)
+}
+
+func memoKey(opts MemoOption, args ...any) any {
+	if opts.KeyFunc != nil {
+		return opts.KeyFunc(args...)
+	}
+	switch len(args) {
+	case 0:
+		return struct{}{}
+	case 1:
+		return argKey(args[0])
+	case 2:
+		return [2]any{argKey(args[0]), argKey(args[1])}
+	case 3:
+		return [3]any{argKey(args[0]), argKey(args[1]), argKey(args[2])}
+	}
+	return [4]any{argKey(args[0]), argKey(args[1]), argKey(args[2]), argKey(args[3])}
+}
+
+type encodedKey struct {
+	typ reflect.Type
+	sum [sha256.Size]byte
+}
+
+// 可比较的参数直接作为键，切片、map等不可比较的参数按内容计算摘要
+// map 的键值对按摘要排序后写入，相同内容的参数得到相同的键，键中也不会持有参数本身
+func argKey(arg any) any {
+	t := reflect.TypeOf(arg)
+	if t == nil || t.Comparable() {
+		return arg
+	}
+	h := sha256.New()
+	writeArg(h, reflect.ValueOf(arg), map[uintptr]bool{})
+	key := encodedKey{typ: t}
+	h.Sum(key.sum[:0])
+	return key
+}
+
+// 按类型写入值的内容，visited 记录当前路径上的指针，避免循环引用
+func writeArg(h hash.Hash, v reflect.Value, visited map[uintptr]bool) {
+	var buf [8]byte
+	writeUint := func(n uint64) {
+		binary.LittleEndian.PutUint64(buf[:], n)
+		h.Write(buf[:])
+	}
+	writeUint(uint64(v.Kind()))
+	switch v.Kind() {
+	case reflect.Invalid:
+	case reflect.Bool:
+		if v.Bool() {
+			writeUint(1)
+		} else {
+			writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeUint(math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		writeUint(math.Float64bits(real(v.Complex())))
+		writeUint(math.Float64bits(imag(v.Complex())))
+	case reflect.String:
+		writeUint(uint64(v.Len()))
+		h.Write([]byte(v.String()))
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			writeUint(math.MaxUint64)
+			return
+		}
+		writeUint(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			writeArg(h, v.Index(i), visited)
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			writeUint(math.MaxUint64)
+			return
+		}
+		writeUint(uint64(v.Len()))
+		sums := make([][]byte, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			eh := sha256.New()
+			writeArg(eh, iter.Key(), visited)
+			writeArg(eh, iter.Value(), visited)
+			sums = append(sums, eh.Sum(nil))
+		}
+		sort.Slice(sums, func(i, j int) bool {
+			return bytes.Compare(sums[i], sums[j]) < 0
+		})
+		for _, sum := range sums {
+			h.Write(sum)
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			writeUint(0)
+			return
+		}
+		ptr := v.Pointer()
+		if visited[ptr] {
+			// 循环引用只记录地址
+			writeUint(uint64(ptr))
+			return
+		}
+		writeUint(1)
+		visited[ptr] = true
+		writeArg(h, v.Elem(), visited)
+		delete(visited, ptr)
+	case reflect.Interface:
+		if v.IsNil() {
+			writeUint(0)
+			return
+		}
+		writeUint(1)
+		h.Write([]byte(v.Elem().Type().String()))
+		writeArg(h, v.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			writeArg(h, v.Field(i), visited)
+		}
+	default:
+		// 函数、channel 等按地址区分
+		writeUint(uint64(v.Pointer()))
+	}
+}
+
+func memoLoad[R any](c Cache[any, R], key any, fn func() (R, error)) (R, error) {
+	return c.GetOrLoad(key, func(ctx context.Context) (R, time.Duration, error) {
+		r, err := fn()
+		return r, 0, err
+	})
+}
+
+// 缓存函数的结果，相同参数的并发调用只会执行一次，返回错误时不缓存
+func Memoize[A, R any](fn func(A) (R, error), opts MemoOption) func(A) (R, error) {
+	c := newMemoCache[R](opts)
+	return func(a A) (R, error) {
+		return memoLoad(c, memoKey(opts, a), func() (R, error) {
+			return fn(a)
+		})
+	}
+}
+
+func Memoize_0[R any](fn func() (R, error), opts MemoOption) func() (R, error) {
+	c := newMemoCache[R](opts)
+	return func() (R, error) {
+		return memoLoad(c, memoKey(opts), fn)
+	}
+}
+
+func Memoize_2[A0, A1, R any](fn func(A0, A1) (R, error), opts MemoOption) func(A0, A1) (R, error) {
+	c := newMemoCache[R](opts)
+	return func(a0 A0, a1 A1) (R, error) {
+		return memoLoad(c, memoKey(opts, a0, a1), func() (R, error) {
+			return fn(a0, a1)
+		})
+	}
+}
+
+func Memoize_3[A0, A1, A2, R any](fn func(A0, A1, A2) (R, error), opts MemoOption) func(A0, A1, A2) (R, error) {
+	c := newMemoCache[R](opts)
+	return func(a0 A0, a1 A1, a2 A2) (R, error) {
+		return memoLoad(c, memoKey(opts, a0, a1, a2), func() (R, error) {
+			return fn(a0, a1, a2)
+		})
+	}
+}
+
+func Memoize_4[A0, A1, A2, A3, R any](fn func(A0, A1, A2, A3) (R, error), opts MemoOption) func(A0, A1, A2, A3) (R, error) {
+	c := newMemoCache[R](opts)
+	return func(a0 A0, a1 A1, a2 A2, a3 A3) (R, error) {
+		return memoLoad(c, memoKey(opts, a0, a1, a2, a3), func() (R, error) {
+			return fn(a0, a1, a2, a3)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package cachex
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 测试：相同参数只执行一次，错误不缓存
+func TestMemoize(t *testing.T) {
+	var calls int32
+	square := Memoize(func(n int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		if n < 0 {
+			return 0, errors.New("negative")
+		}
+		return n * n, nil
+	}, MemoOption{})
+
+	for i := 0; i < 3; i++ {
+		if v, err := square(3); err != nil || v != 9 {
+			t.Fatalf("want 9, got %v %v", v, err)
+		}
+	}
+	square(-1)
+	square(-1)
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Fatalf("want 3 calls, got %d", n)
+	}
+}
+
+// 测试：不可比较的参数和多参数
+func TestMemoizeKeys(t *testing.T) {
+	var calls int32
+	join := Memoize_2(func(parts []string, sep string) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return strings.Join(parts, sep), nil
+	}, MemoOption{})
+
+	join([]string{"a", "b"}, ",")
+	join([]string{"a", "b"}, ",")
+	join([]string{"a", "b"}, "-")
+	if v, _ := join([]string{"a", "c"}, ","); v != "a,c" {
+		t.Fatalf("want a,c, got %v", v)
+	}
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Fatalf("want 3 calls, got %d", n)
+	}
+
+	// map 参数的键与遍历顺序无关
+	calls = 0
+	sum := Memoize(func(m map[string]int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		n := 0
+		for _, v := range m {
+			n += v
+		}
+		return n, nil
+	}, MemoOption{})
+	for i := 0; i < 50; i++ {
+		sum(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5})
+	}
+	if v, _ := sum(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 6}); v != 16 {
+		t.Fatalf("want 16, got %v", v)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Fatalf("want 2 calls, got %d", n)
+	}
+
+	// 自定义键
+	calls = 0
+	lower := Memoize(func(s string) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return strings.ToLower(s), nil
+	}, MemoOption{KeyFunc: func(args ...any) any {
+		return strings.ToLower(args[0].(string))
+	}})
+	lower("ABC")
+	lower("abc")
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("want 1 call, got %d", n)
+	}
+}
+
+// 测试：并发调用合并，过期后重新计算
+func TestMemoizeConcurrentExpire(t *testing.T) {
+	var calls int32
+	slow := Memoize_0(func() (int, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return 1, nil
+	}, MemoOption{CacheOption: CacheOption{DefaultKeyExpire: 50 * time.Millisecond}})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slow()
+		}()
+	}
+	wg.Wait()
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("want 1 call, got %d", n)
+	}
+	time.Sleep(60 * time.Millisecond)
+	slow()
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Fatalf("want 2 calls after expire, got %d", n)
+	}
+}
+
+// 测试：只设置 DefaultKeyExpire 时不启动后台协程，过期的键在写入时清理
+func TestMemoizeExpireWithoutSweeper(t *testing.T) {
+	before := runtime.NumGoroutine()
+	var caches []Cache[any, int]
+	for i := 0; i < 50; i++ {
+		caches = append(caches, newMemoCache[int](MemoOption{CacheOption: CacheOption{DefaultKeyExpire: 10 * time.Millisecond}}))
+	}
+	if n := runtime.NumGoroutine() - before; n >= 50 {
+		t.Fatalf("want no background goroutines, got %d", n)
+	}
+
+	c := caches[0].(*baseCache[any, int])
+	c.Set(1, 1)
+	c.Set(2, 2)
+	time.Sleep(20 * time.Millisecond)
+	c.Set(3, 3)
+	if n := c.Len(); n != 1 {
+		t.Fatalf("want 1, got %d", n)
+	}
+}
+
+// 测试：设置 Expire 时直接 panic，避免缓存到期关闭后函数无法再使用
+func TestMemoizeRejectExpire(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("want panic with Expire")
+		}
+	}()
+	Memoize(func(n int) (int, error) {
+		return n, nil
+	}, MemoOption{CacheOption: CacheOption{Expire: 10 * time.Millisecond}})
+}
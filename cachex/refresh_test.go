@@ -0,0 +1,68 @@
+package cachex
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 测试：超过软过期时间后读取返回旧值，并只触发一次后台刷新
+func TestRefreshAhead(t *testing.T) {
+	cache := NewBaseCache[string, int](CacheOption{RefreshAfter: 10 * time.Millisecond})
+	defer cache.Destroy()
+
+	var calls int32
+	release := make(chan struct{})
+	cache.SetLoader(func(ctx context.Context, key string) (int, time.Duration, error) {
+		<-release
+		return int(atomic.AddInt32(&calls, 1)) + 1, 0, nil
+	})
+	cache.Set("k", 1)
+	time.Sleep(20 * time.Millisecond)
+
+	for i := 0; i < 10; i++ {
+		if v, ok := cache.Get("k"); !ok || v != 1 {
+			t.Fatalf("want stale 1, got %v %v", v, ok)
+		}
+	}
+	close(release)
+	time.Sleep(20 * time.Millisecond)
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("want 1 refresh, got %d", n)
+	}
+	if v, _ := cache.Get("k"); v != 2 {
+		t.Fatalf("want refreshed 2, got %v", v)
+	}
+}
+
+// 测试：超过硬过期时间后 GetOrLoad 阻塞加载，软过期时使用调用方的加载函数刷新
+func TestStaleWhileRevalidate(t *testing.T) {
+	cache := NewBaseCache[string, int](CacheOption{
+		RefreshAfter:     10 * time.Millisecond,
+		DefaultKeyExpire: 50 * time.Millisecond,
+	})
+	defer cache.Destroy()
+
+	var version int32
+	load := func(ctx context.Context) (int, time.Duration, error) {
+		return int(atomic.AddInt32(&version, 1)), 0, nil
+	}
+	if v, _ := cache.GetOrLoad("k", load); v != 1 {
+		t.Fatalf("want 1, got %v", v)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if v, _ := cache.GetOrLoad("k", load); v != 1 {
+		t.Fatalf("want stale 1, got %v", v)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if v, _ := cache.GetOrLoad("k", load); v != 2 {
+		t.Fatalf("want refreshed 2, got %v", v)
+	}
+
+	// 硬过期后返回的是本次同步加载的最新值
+	time.Sleep(60 * time.Millisecond)
+	if v, _ := cache.GetOrLoad("k", load); v != int(atomic.LoadInt32(&version)) {
+		t.Fatalf("want blocking load %d, got %v", version, v)
+	}
+}
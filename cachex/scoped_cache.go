@@ -0,0 +1,149 @@
+package cachex
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/llyb120/yoya/syncx"
+)
+
+// 当前协程所在的作用域，通过 syncx.Group 启动的子协程可以读到父协程的作用域
+var scopeHolder syncx.Holder[*scope]
+
+type scope struct {
+	mu     sync.Mutex
+	caches map[any]interface{ Close() error }
+	closed bool
+}
+
+func (sc *scope) close() {
+	sc.mu.Lock()
+	caches := sc.caches
+	sc.caches = nil
+	sc.closed = true
+	sc.mu.Unlock()
+	for _, c := range caches {
+		c.Close()
+	}
+}
+
+// 开启一个作用域，fn 执行期间当前协程及其通过 syncx.Group 启动的子协程共享同一组作用域缓存
+// fn 返回后作用域内的缓存全部清理，嵌套调用时内层作用域使用独立的缓存
+func Scope(fn func()) {
+	sc := &scope{caches: make(map[any]interface{ Close() error })}
+	prev := scopeHolder.Del()
+	scopeHolder.Set(sc)
+	defer func() {
+		if prev != nil {
+			scopeHolder.Set(prev)
+		} else {
+			scopeHolder.Del()
+		}
+		sc.close()
+	}()
+	fn()
+}
+
+// 作用域缓存，数据只在 Scope 内可见，作用域结束时自动清理
+// 不在任何作用域内时读取总是未命中，写入不生效，GetOrSetFunc / GetOrLoad 直接调用加载函数
+type scopedCache[K comparable, V any] struct {
+	opts   CacheOption
+	closed atomic.Bool
+}
+
+func NewScopedCache[K comparable, V any](opts CacheOption) *scopedCache[K, V] {
+	return &scopedCache[K, V]{opts: opts}
+}
+
+// 获取当前作用域中的缓存，首次访问时创建
+func (s *scopedCache[K, V]) current() *baseCache[K, V] {
+	if s.closed.Load() {
+		return nil
+	}
+	sc := scopeHolder.Get()
+	if sc == nil {
+		return nil
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.closed {
+		return nil
+	}
+	if c, ok := sc.caches[s]; ok {
+		return c.(*baseCache[K, V])
+	}
+	var c *baseCache[K, V]
+	if s.opts.bounded() {
+		c = NewLRUCache[K, V](s.opts)
+	} else {
+		c = NewBaseCache[K, V](s.opts)
+	}
+	sc.caches[s] = c
+	return c
+}
+
+func (s *scopedCache[K, V]) Get(key K) (V, bool) {
+	if c := s.current(); c != nil {
+		return c.Get(key)
+	}
+	var zero V
+	return zero, false
+}
+
+func (s *scopedCache[K, V]) Gets(keys ...K) []V {
+	if c := s.current(); c != nil {
+		return c.Gets(keys...)
+	}
+	return []V{}
+}
+
+func (s *scopedCache[K, V]) Set(key K, value V) {
+	if c := s.current(); c != nil {
+		c.Set(key, value)
+	}
+}
+
+func (s *scopedCache[K, V]) SetExpire(key K, value V, expire time.Duration) {
+	if c := s.current(); c != nil {
+		c.SetExpire(key, value, expire)
+	}
+}
+
+func (s *scopedCache[K, V]) Del(key ...K) {
+	if c := s.current(); c != nil {
+		c.Del(key...)
+	}
+}
+
+func (s *scopedCache[K, V]) Clear() {
+	if c := s.current(); c != nil {
+		c.Clear()
+	}
+}
+
+func (s *scopedCache[K, V]) Destroy() {
+	s.Close()
+}
+
+// 关闭后在任何作用域内都不再缓存，已创建的缓存随各自的作用域清理
+func (s *scopedCache[K, V]) Close() error {
+	s.closed.Store(true)
+	return nil
+}
+
+func (s *scopedCache[K, V]) GetOrSetFunc(key K, fn func() V) V {
+	if c := s.current(); c != nil {
+		return c.GetOrSetFunc(key, fn)
+	}
+	return fn()
+}
+
+func (s *scopedCache[K, V]) GetOrLoad(key K, fn LoadFunc[V]) (V, error) {
+	if c := s.current(); c != nil {
+		return c.GetOrLoad(key, fn)
+	}
+	value, _, err := fn(context.Background())
+	return value, err
+}
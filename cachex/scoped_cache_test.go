@@ -0,0 +1,77 @@
+package cachex
+
+import (
+	"testing"
+
+	"github.com/llyb120/yoya/syncx"
+)
+
+// 测试：作用域内的子协程可以读取父协程写入的值，作用域结束后数据清空
+func TestScopedCache(t *testing.T) {
+	cache := NewScopedCache[string, int](CacheOption{})
+	cache.Set("a", 1)
+	if _, ok := cache.Get("a"); ok {
+		t.Fatalf("want miss outside scope")
+	}
+
+	Scope(func() {
+		cache.Set("a", 1)
+		var g syncx.Group
+		g.Go(func() error {
+			if v, ok := cache.Get("a"); !ok || v != 1 {
+				t.Errorf("want 1, got %v %v", v, ok)
+			}
+			cache.Set("b", 2)
+			return nil
+		})
+		g.Wait()
+		if v, ok := cache.Get("b"); !ok || v != 2 {
+			t.Fatalf("want 2, got %v %v", v, ok)
+		}
+	})
+
+	Scope(func() {
+		if _, ok := cache.Get("a"); ok {
+			t.Fatalf("want miss in new scope")
+		}
+	})
+}
+
+// 测试：嵌套作用域结束后恢复外层作用域
+func TestScopedCacheNested(t *testing.T) {
+	cache := NewScopedCache[string, int](CacheOption{})
+	Scope(func() {
+		cache.Set("a", 1)
+		Scope(func() {
+			if _, ok := cache.Get("a"); ok {
+				t.Fatalf("want miss in inner scope")
+			}
+			cache.Set("a", 2)
+		})
+		if v, _ := cache.Get("a"); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+	})
+	if scopeHolder.Get() != nil {
+		t.Fatalf("want no scope after exit")
+	}
+}
+
+// 测试：作用域外 GetOrSetFunc 每次都调用函数
+func TestScopedCacheOutside(t *testing.T) {
+	cache := NewScopedCache[string, int](CacheOption{})
+	calls := 0
+	fn := func() int { calls++; return calls }
+	cache.GetOrSetFunc("a", fn)
+	cache.GetOrSetFunc("a", fn)
+	if calls != 2 {
+		t.Fatalf("want 2 calls, got %d", calls)
+	}
+	Scope(func() {
+		cache.GetOrSetFunc("a", fn)
+		cache.GetOrSetFunc("a", fn)
+	})
+	if calls != 3 {
+		t.Fatalf("want 3 calls, got %d", calls)
+	}
+}
@@ -0,0 +1,346 @@
+package cachex
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/llyb120/yoya/strx"
+)
+
+// 分片缓存，按键的哈希值分散到多个 baseCache 上，每个分片持有独立的锁和清理协程
+type shardedCache[K comparable, V any] struct {
+	shards  []*baseCache[K, V]
+	mask    uint64
+	hasher  func(K) uint64
+	opts    CacheOption
+	cancel  context.CancelFunc
+	closing atomic.Bool
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// 创建分片缓存，分片数由 CacheOption.Shards 指定，会向上取整为2的幂
+// 设置了 MaxEntries / MaxWeight 时每个分片使用LRU淘汰，容量按分片均分，各分片的上限之和等于总上限
+// 分片数不会超过 MaxEntries / MaxWeight，保证每个分片至少能容纳一个条目
+// hasher 用于计算键的哈希值，不传时对字符串和整数使用内置实现，其余类型使用 fmt 格式化后哈希
+func NewShardedCache[K comparable, V any](opts CacheOption, hasher ...func(K) uint64) *shardedCache[K, V] {
+	return newShardedCache[K, V](context.Background(), opts, hasher)
+}
+
+// 创建绑定到 ctx 的分片缓存，ctx 取消时所有分片随之关闭
+func NewShardedCacheWithContext[K comparable, V any](ctx context.Context, opts CacheOption, hasher ...func(K) uint64) *shardedCache[K, V] {
+	return newShardedCache[K, V](ctx, opts, hasher)
+}
+
+func newShardedCache[K comparable, V any](parent context.Context, opts CacheOption, hasher []func(K) uint64) *shardedCache[K, V] {
+	n := 1
+	shards := opts.Shards
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0) * 4
+	}
+	for n < shards {
+		n <<= 1
+	}
+	// 分片数多于容量时每个分片至少占一个名额，总数会超过上限，因此减少到不超过容量的2的幂
+	for n > 1 && ((opts.MaxEntries > 0 && n > opts.MaxEntries) || (opts.MaxWeight > 0 && int64(n) > opts.MaxWeight)) {
+		n >>= 1
+	}
+	c := &shardedCache[K, V]{
+		shards: make([]*baseCache[K, V], n),
+		mask:   uint64(n - 1),
+		hasher: defaultHasher[K],
+		opts:   opts,
+	}
+	if len(hasher) > 0 && hasher[0] != nil {
+		c.hasher = hasher[0]
+	}
+
+	// 整体的生命周期由分片缓存管理，分片自身不再过期
+	shardOpts := opts
+	shardOpts.Expire = 0
+	shardOpts.Destroy = nil
+	// 到期或父ctx取消时关闭整个缓存，分片使用同一个ctx，加载函数收到的ctx也由它派生
+	var ctx context.Context
+	if opts.Expire > 0 {
+		ctx, c.cancel = context.WithTimeout(parent, opts.Expire)
+	} else {
+		ctx, c.cancel = context.WithCancel(parent)
+	}
+	watch := opts.Expire > 0 || parent.Done() != nil
+	shardCtx := context.Background()
+	if watch {
+		shardCtx = ctx
+	}
+	for i := range c.shards {
+		// 余数分给前面的分片
+		if opts.MaxEntries > 0 {
+			shardOpts.MaxEntries = opts.MaxEntries / n
+			if i < opts.MaxEntries%n {
+				shardOpts.MaxEntries++
+			}
+		}
+		if opts.MaxWeight > 0 {
+			shardOpts.MaxWeight = opts.MaxWeight / int64(n)
+			if int64(i) < opts.MaxWeight%int64(n) {
+				shardOpts.MaxWeight++
+			}
+		}
+		if opts.bounded() {
+			c.shards[i] = NewLRUCacheWithContext[K, V](shardCtx, shardOpts)
+		} else {
+			c.shards[i] = NewBaseCacheWithContext[K, V](shardCtx, shardOpts)
+		}
+	}
+
+	if watch {
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			<-ctx.Done()
+			if !c.closing.Load() {
+				// Close 会等待本协程退出，需要在新的协程中调用
+				go c.Close()
+			}
+		}()
+	}
+	return c
+}
+
+func (c *shardedCache[K, V]) shard(key K) *baseCache[K, V] {
+	return c.shards[c.hasher(key)&c.mask]
+}
+
+func (c *shardedCache[K, V]) Get(key K) (V, bool) {
+	return c.shard(key).Get(key)
+}
+
+func (c *shardedCache[K, V]) Gets(keys ...K) []V {
+	values := make([]V, 0, len(keys))
+	for _, key := range keys {
+		if value, ok := c.Get(key); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (c *shardedCache[K, V]) Set(key K, value V) {
+	c.shard(key).Set(key, value)
+}
+
+func (c *shardedCache[K, V]) SetExpire(key K, value V, expire time.Duration) {
+	c.shard(key).SetExpire(key, value, expire)
+}
+
+func (c *shardedCache[K, V]) SetCost(key K, value V, cost int64) {
+	c.shard(key).SetCost(key, value, cost)
+}
+
+func (c *shardedCache[K, V]) SetWithTags(key K, value V, expire time.Duration, tags ...string) {
+	c.shard(key).SetWithTags(key, value, expire, tags...)
+}
+
+func (c *shardedCache[K, V]) InvalidateTag(tags ...string) int {
+	n := 0
+	for _, s := range c.shards {
+		n += s.InvalidateTag(tags...)
+	}
+	return n
+}
+
+func (c *shardedCache[K, V]) DelPrefix(prefix string) int {
+	n := 0
+	for _, s := range c.shards {
+		n += s.DelPrefix(prefix)
+	}
+	return n
+}
+
+func (c *shardedCache[K, V]) DelMatch(pattern string) (int, error) {
+	match, err := strx.LikeFunc(pattern)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, s := range c.shards {
+		n += s.delWhere(match)
+	}
+	return n, nil
+}
+
+func (c *shardedCache[K, V]) SetMap(m map[K]V) {
+	for key, value := range m {
+		c.Set(key, value)
+	}
+}
+
+func (c *shardedCache[K, V]) Del(key ...K) {
+	for _, k := range key {
+		c.shard(k).Del(k)
+	}
+}
+
+func (c *shardedCache[K, V]) Clear() {
+	for _, s := range c.shards {
+		s.Clear()
+	}
+}
+
+func (c *shardedCache[K, V]) Len() int {
+	n := 0
+	for _, s := range c.shards {
+		n += s.Len()
+	}
+	return n
+}
+
+func (c *shardedCache[K, V]) Destroy() {
+	c.Close()
+}
+
+// 关闭所有分片，可以重复调用，返回时所有后台协程都已退出
+func (c *shardedCache[K, V]) Close() error {
+	c.once.Do(func() {
+		c.closing.Store(true)
+		c.cancel()
+		c.wg.Wait()
+		for _, s := range c.shards {
+			s.Close()
+		}
+		if c.opts.Destroy != nil {
+			c.opts.Destroy()
+		}
+	})
+	return nil
+}
+
+func (c *shardedCache[K, V]) GetOrSetFunc(key K, fn func() V) V {
+	return c.shard(key).GetOrSetFunc(key, fn)
+}
+
+func (c *shardedCache[K, V]) GetOrLoad(key K, fn LoadFunc[V]) (V, error) {
+	return c.shard(key).GetOrLoad(key, fn)
+}
+
+func (c *shardedCache[K, V]) SetLoader(fn KeyLoadFunc[K, V]) {
+	for _, s := range c.shards {
+		s.SetLoader(fn)
+	}
+}
+
+func (c *shardedCache[K, V]) SetWeigher(fn func(key K, value V) int64) {
+	for _, s := range c.shards {
+		s.SetWeigher(fn)
+	}
+}
+
+func (c *shardedCache[K, V]) OnEvict(fn EvictListener[K, V]) {
+	for _, s := range c.shards {
+		s.OnEvict(fn)
+	}
+}
+
+// 汇总所有分片的统计数据
+func (c *shardedCache[K, V]) Stats() CacheStats {
+	var stats CacheStats
+	for _, s := range c.shards {
+		st := s.Stats()
+		stats.Hits += st.Hits
+		stats.Misses += st.Misses
+		stats.LoadSuccess += st.LoadSuccess
+		stats.LoadFailure += st.LoadFailure
+		stats.TotalLoadTime += st.TotalLoadTime
+		stats.Evictions += st.Evictions
+		stats.Expirations += st.Expirations
+		stats.Rejections += st.Rejections
+		stats.Size += st.Size
+		stats.Weight += st.Weight
+	}
+	return stats
+}
+
+func (c *shardedCache[K, V]) ResetStats() {
+	for _, s := range c.shards {
+		s.ResetStats()
+	}
+}
+
+func defaultHasher[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return hashString(k)
+	case int:
+		return mix64(uint64(k))
+	case int8:
+		return mix64(uint64(k))
+	case int16:
+		return mix64(uint64(k))
+	case int32:
+		return mix64(uint64(k))
+	case int64:
+		return mix64(uint64(k))
+	case uint:
+		return mix64(uint64(k))
+	case uint8:
+		return mix64(uint64(k))
+	case uint16:
+		return mix64(uint64(k))
+	case uint32:
+		return mix64(uint64(k))
+	case uint64:
+		return mix64(k)
+	case uintptr:
+		return mix64(uint64(k))
+	}
+	return hashString(fmt.Sprintf("%#v", key))
+}
+
+// FNV-1a，避免 hash/fnv 的内存分配
+func hashString(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+// 打散整数的低位，避免连续的键落在相邻的分片
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// 将所有分片未过期的条目写入 w，每个分片在各自的读锁下收集
+func (c *shardedCache[K, V]) Dump(w io.Writer, codec ...Codec) error {
+	entries := make([]snapshotEntry[K, V], 0)
+	for _, s := range c.shards {
+		entries = append(entries, s.snapshot()...)
+	}
+	return writeSnapshot(w, entries, codec)
+}
+
+func (c *shardedCache[K, V]) Load(r io.Reader, codec ...Codec) error {
+	entries, err := readSnapshot[K, V](r, codec)
+	if err != nil {
+		return err
+	}
+	groups := make([][]snapshotEntry[K, V], len(c.shards))
+	for _, entry := range entries {
+		i := c.hasher(entry.Key) & c.mask
+		groups[i] = append(groups[i], entry)
+	}
+	for i, s := range c.shards {
+		s.restore(groups[i])
+	}
+	return nil
+}
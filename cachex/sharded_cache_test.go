@@ -0,0 +1,137 @@
+package cachex
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var _ Cache[string, int] = (*shardedCache[string, int])(nil)
+
+// 测试：基本读写删除
+func TestShardedCache(t *testing.T) {
+	cache := NewShardedCache[string, int](CacheOption{Shards: 8})
+	defer cache.Destroy()
+
+	for i := 0; i < 100; i++ {
+		cache.Set(strconv.Itoa(i), i)
+	}
+	if cache.Len() != 100 {
+		t.Fatalf("want len 100, got %d", cache.Len())
+	}
+	if v, ok := cache.Get("42"); !ok || v != 42 {
+		t.Fatalf("want 42, got %v %v", v, ok)
+	}
+	if vals := cache.Gets("1", "x", "2"); len(vals) != 2 || vals[0] != 1 || vals[1] != 2 {
+		t.Fatalf("want [1 2], got %v", vals)
+	}
+	cache.Del("42")
+	if _, ok := cache.Get("42"); ok {
+		t.Fatalf("want 42 deleted")
+	}
+	cache.Clear()
+	if cache.Len() != 0 {
+		t.Fatalf("want empty, got %d", cache.Len())
+	}
+}
+
+// 测试：容量按分片均分，自定义哈希
+func TestShardedCacheBounded(t *testing.T) {
+	type key struct{ id int }
+	cache := NewShardedCache[key, int](CacheOption{Shards: 4, MaxEntries: 8}, func(k key) uint64 {
+		return uint64(k.id)
+	})
+	defer cache.Destroy()
+
+	for i := 0; i < 100; i++ {
+		cache.Set(key{i}, i)
+	}
+	if cache.Len() != 8 {
+		t.Fatalf("want len 8, got %d", cache.Len())
+	}
+	if _, ok := cache.Get(key{99}); !ok {
+		t.Fatalf("want latest key kept")
+	}
+}
+
+// 测试：分片数多于容量时总数仍不超过 MaxEntries / MaxWeight
+func TestShardedCacheBoundedManyShards(t *testing.T) {
+	cache := NewShardedCache[int, int](CacheOption{Shards: 64, MaxEntries: 10})
+	defer cache.Destroy()
+	for i := 0; i < 1000; i++ {
+		cache.Set(i, i)
+	}
+	if n := cache.Len(); n > 10 {
+		t.Fatalf("want len <= 10, got %d", n)
+	}
+
+	weighted := NewShardedCache[int, int](CacheOption{Shards: 64, MaxWeight: 10})
+	defer weighted.Destroy()
+	for i := 0; i < 1000; i++ {
+		weighted.SetCost(i, i, 1)
+	}
+	if w := weighted.Stats().Weight; w > 10 {
+		t.Fatalf("want weight <= 10, got %d", w)
+	}
+
+	// 余数分给前面的分片，各分片上限之和等于总上限
+	uneven := NewShardedCache[int, int](CacheOption{Shards: 4, MaxEntries: 10})
+	defer uneven.Destroy()
+	total := 0
+	for _, s := range uneven.shards {
+		total += s.opts.MaxEntries
+	}
+	if total != 10 {
+		t.Fatalf("want 10, got %d", total)
+	}
+}
+
+// 测试：整体过期只触发一次销毁回调
+func TestShardedCacheExpire(t *testing.T) {
+	var destroyed int32
+	cache := NewShardedCache[int, int](CacheOption{
+		Expire: 20 * time.Millisecond,
+		Destroy: func() {
+			atomic.AddInt32(&destroyed, 1)
+		},
+	})
+	time.Sleep(60 * time.Millisecond)
+	cache.Destroy()
+	if n := atomic.LoadInt32(&destroyed); n != 1 {
+		t.Fatalf("want destroy once, got %d", n)
+	}
+}
+
+func benchmarkParallel(b *testing.B, cache Cache[string, int]) {
+	keys := make([]string, 1024)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+		cache.Set(keys[i], i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := keys[i&1023]
+			if i%10 == 0 {
+				cache.Set(key, i)
+			} else {
+				cache.Get(key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkBaseCacheParallel(b *testing.B) {
+	cache := NewBaseCache[string, int](CacheOption{})
+	defer cache.Destroy()
+	benchmarkParallel(b, cache)
+}
+
+func BenchmarkShardedCacheParallel(b *testing.B) {
+	cache := NewShardedCache[string, int](CacheOption{})
+	defer cache.Destroy()
+	benchmarkParallel(b, cache)
+}
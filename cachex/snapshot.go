@@ -0,0 +1,92 @@
+package cachex
+
+import (
+	"io"
+	"time"
+)
+
+// 快照中的条目，记录到期的时间点，恢复时扣除停机期间流逝的时间
+type snapshotEntry[K comparable, V any] struct {
+	Key      K
+	Value    V
+	ExpireAt time.Time
+	Cost     int64
+	Tags     []string
+}
+
+// 将未过期的条目写入 w，codec 不传时使用 GobCodec
+// 条目在读锁下一次性收集，保证快照的一致性
+func (c *baseCache[K, V]) Dump(w io.Writer, codec ...Codec) error {
+	if c.closed.Load() {
+		return ErrClosed
+	}
+	return writeSnapshot(w, c.snapshot(), codec)
+}
+
+// 从 r 中恢复条目，已经过期的条目会被跳过
+func (c *baseCache[K, V]) Load(r io.Reader, codec ...Codec) error {
+	if c.closed.Load() {
+		return ErrClosed
+	}
+	entries, err := readSnapshot[K, V](r, codec)
+	if err != nil {
+		return err
+	}
+	c.restore(entries)
+	return nil
+}
+
+func (c *baseCache[K, V]) snapshot() []snapshotEntry[K, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := time.Now()
+	entries := make([]snapshotEntry[K, V], 0, len(c.cache))
+	for key, item := range c.cache {
+		if item.expired(now) {
+			continue
+		}
+		entry := snapshotEntry[K, V]{Key: key, Value: item.value, Cost: item.weight, Tags: item.tags}
+		if item.canExpire {
+			entry.ExpireAt = item.expire
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (c *baseCache[K, V]) restore(entries []snapshotEntry[K, V]) {
+	c.mu.Lock()
+	defer c.unlockAndNotify()
+	now := time.Now()
+	for _, entry := range entries {
+		var expire time.Duration
+		if !entry.ExpireAt.IsZero() {
+			if expire = entry.ExpireAt.Sub(now); expire <= 0 {
+				continue
+			}
+		}
+		c.setItem(entry.Key, entry.Value, expire, entry.Cost)
+		c.tag(entry.Key, entry.Tags)
+	}
+}
+
+func writeSnapshot[K comparable, V any](w io.Writer, entries []snapshotEntry[K, V], codec []Codec) error {
+	data, err := pickCodec(codec).Marshal(entries)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func readSnapshot[K comparable, V any](r io.Reader, codec []Codec) ([]snapshotEntry[K, V], error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var entries []snapshotEntry[K, V]
+	if err := pickCodec(codec).Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,73 @@
+package cachex
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type snapshotUser struct {
+	Name string
+	Age  int
+}
+
+// 测试：快照保留剩余过期时间并跳过已过期的条目
+func TestSnapshot(t *testing.T) {
+	for _, codec := range []Codec{GobCodec, JSONCodec} {
+		src := NewBaseCache[string, snapshotUser](CacheOption{})
+		src.SetExpire("alice", snapshotUser{"alice", 20}, 0)
+		src.SetExpire("bob", snapshotUser{"bob", 30}, time.Hour)
+		src.SetExpire("gone", snapshotUser{"gone", 40}, time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+
+		var buf bytes.Buffer
+		if err := src.Dump(&buf, codec); err != nil {
+			t.Fatalf("dump failed: %v", err)
+		}
+		src.Destroy()
+
+		dst := NewBaseCache[string, snapshotUser](CacheOption{})
+		if err := dst.Load(&buf, codec); err != nil {
+			t.Fatalf("load failed: %v", err)
+		}
+		if dst.Len() != 2 {
+			t.Fatalf("want 2 entries, got %d", dst.Len())
+		}
+		if u, ok := dst.Get("bob"); !ok || u.Age != 30 {
+			t.Fatalf("want bob, got %v %v", u, ok)
+		}
+		if _, ok := dst.Get("gone"); ok {
+			t.Fatalf("want expired entry skipped")
+		}
+		item := dst.cache["bob"]
+		if !item.canExpire || time.Until(item.expire) < 59*time.Minute {
+			t.Fatalf("want remaining ttl kept, got %v", time.Until(item.expire))
+		}
+		if dst.cache["alice"].canExpire {
+			t.Fatalf("want alice never expire")
+		}
+		dst.Destroy()
+	}
+}
+
+// 测试：分片缓存的快照
+func TestShardedSnapshot(t *testing.T) {
+	src := NewShardedCache[int, int](CacheOption{Shards: 4})
+	defer src.Destroy()
+	for i := 0; i < 100; i++ {
+		src.Set(i, i)
+	}
+	var buf bytes.Buffer
+	if err := src.Dump(&buf); err != nil {
+		t.Fatalf("dump failed: %v", err)
+	}
+
+	dst := NewShardedCache[int, int](CacheOption{Shards: 8})
+	defer dst.Destroy()
+	if err := dst.Load(&buf); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if dst.Len() != 100 {
+		t.Fatalf("want 100 entries, got %d", dst.Len())
+	}
+}
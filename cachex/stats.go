@@ -0,0 +1,126 @@
+package cachex
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// 缓存统计数据的快照
+type CacheStats struct {
+	Hits          int64
+	Misses        int64
+	LoadSuccess   int64
+	LoadFailure   int64
+	TotalLoadTime time.Duration
+	// 超出容量被淘汰的条目数
+	Evictions int64
+	// 过期被清理的条目数
+	Expirations int64
+	// 未通过准入过滤被拒绝写入的条目数
+	Rejections int64
+	// 当前总权重，见 MaxWeight
+	Weight int64
+	Size   int
+}
+
+// 命中率，没有任何读取时返回0
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+type cacheStats struct {
+	hits        atomic.Int64
+	misses      atomic.Int64
+	loadSuccess atomic.Int64
+	loadFailure atomic.Int64
+	loadTime    atomic.Int64
+	evictions   atomic.Int64
+	expirations atomic.Int64
+	rejections  atomic.Int64
+}
+
+func (s *cacheStats) recordGet(ok bool) {
+	if s == nil {
+		return
+	}
+	if ok {
+		s.hits.Add(1)
+	} else {
+		s.misses.Add(1)
+	}
+}
+
+func (s *cacheStats) recordLoad(d time.Duration, err error) {
+	if s == nil {
+		return
+	}
+	if err != nil {
+		s.loadFailure.Add(1)
+	} else {
+		s.loadSuccess.Add(1)
+	}
+	s.loadTime.Add(int64(d))
+}
+
+func (s *cacheStats) recordRemove(reason EvictReason) {
+	if s == nil {
+		return
+	}
+	switch reason {
+	case EvictEvicted:
+		s.evictions.Add(1)
+	case EvictExpired:
+		s.expirations.Add(1)
+	case EvictRejected:
+		s.rejections.Add(1)
+	}
+}
+
+func (s *cacheStats) snapshot() CacheStats {
+	if s == nil {
+		return CacheStats{}
+	}
+	return CacheStats{
+		Hits:          s.hits.Load(),
+		Misses:        s.misses.Load(),
+		LoadSuccess:   s.loadSuccess.Load(),
+		LoadFailure:   s.loadFailure.Load(),
+		TotalLoadTime: time.Duration(s.loadTime.Load()),
+		Evictions:     s.evictions.Load(),
+		Expirations:   s.expirations.Load(),
+		Rejections:    s.rejections.Load(),
+	}
+}
+
+func (s *cacheStats) reset() {
+	if s == nil {
+		return
+	}
+	s.hits.Store(0)
+	s.misses.Store(0)
+	s.loadSuccess.Store(0)
+	s.loadFailure.Store(0)
+	s.loadTime.Store(0)
+	s.evictions.Store(0)
+	s.expirations.Store(0)
+	s.rejections.Store(0)
+}
+
+// 获取统计数据，需要开启 CacheOption.EnableStats，未开启时只有 Size 有值
+func (c *baseCache[K, V]) Stats() CacheStats {
+	stats := c.stats.snapshot()
+	c.mu.RLock()
+	stats.Size = len(c.cache)
+	stats.Weight = c.weight
+	c.mu.RUnlock()
+	return stats
+}
+
+// 重置统计计数
+func (c *baseCache[K, V]) ResetStats() {
+	c.stats.reset()
+}
@@ -0,0 +1,59 @@
+package cachex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// 测试：读取、加载、淘汰都会计入统计
+func TestStats(t *testing.T) {
+	cache := NewLRUCache[string, int](CacheOption{MaxEntries: 2, EnableStats: true})
+	defer cache.Destroy()
+
+	cache.Set("a", 1)
+	cache.Get("a")
+	cache.Get("b")
+	cache.Gets("a", "c")
+	cache.GetOrSetFunc("d", func() int { return 4 })
+	cache.GetOrLoad("e", func(ctx context.Context) (int, time.Duration, error) {
+		return 0, 0, errors.New("fail")
+	})
+
+	stats := cache.Stats()
+	if stats.Hits != 2 {
+		t.Fatalf("want 2 hits, got %d", stats.Hits)
+	}
+	if stats.Misses != 4 {
+		t.Fatalf("want 4 misses, got %d", stats.Misses)
+	}
+	if stats.LoadSuccess != 1 || stats.LoadFailure != 1 {
+		t.Fatalf("want 1 success 1 failure, got %d %d", stats.LoadSuccess, stats.LoadFailure)
+	}
+	if stats.Size != 2 {
+		t.Fatalf("want size 2, got %d", stats.Size)
+	}
+
+	cache.Set("f", 6)
+	if stats = cache.Stats(); stats.Evictions != 1 {
+		t.Fatalf("want 1 eviction, got %d", stats.Evictions)
+	}
+
+	cache.ResetStats()
+	if stats = cache.Stats(); stats.Hits != 0 || stats.Evictions != 0 || stats.Size != 2 {
+		t.Fatalf("want reset stats, got %+v", stats)
+	}
+}
+
+// 测试：未开启统计时只返回大小
+func TestStatsDisabled(t *testing.T) {
+	cache := NewBaseCache[string, int](CacheOption{})
+	defer cache.Destroy()
+
+	cache.Set("a", 1)
+	cache.Get("a")
+	if stats := cache.Stats(); stats.Hits != 0 || stats.Size != 1 {
+		t.Fatalf("want only size, got %+v", stats)
+	}
+}
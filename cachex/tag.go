@@ -0,0 +1,110 @@
+package cachex
+
+import (
+	"strings"
+	"time"
+
+	"github.com/llyb120/yoya/strx"
+)
+
+// 写入带标签的键值，之后可以通过 InvalidateTag 按标签批量删除
+// 再次写入同一个键时，标签以最后一次写入为准
+func (c *baseCache[K, V]) SetWithTags(key K, value V, expire time.Duration, tags ...string) {
+	c.mu.Lock()
+	defer c.unlockAndNotify()
+	if c.closed.Load() {
+		return
+	}
+	c.setItem(key, value, expire, c.weigh(key, value))
+	c.tag(key, tags)
+}
+
+// 需要在持有写锁时调用
+func (c *baseCache[K, V]) tag(key K, tags []string) {
+	item, ok := c.cache[key]
+	if !ok || len(tags) == 0 {
+		return
+	}
+	if c.tags == nil {
+		c.tags = make(map[string]map[K]struct{})
+	}
+	item.tags = tags
+	c.cache[key] = item
+	for _, tag := range tags {
+		keys, ok := c.tags[tag]
+		if !ok {
+			keys = make(map[K]struct{})
+			c.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+}
+
+// 需要在持有写锁时调用
+func (c *baseCache[K, V]) untag(key K, tags []string) {
+	for _, tag := range tags {
+		keys := c.tags[tag]
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
+
+// 删除带有任意一个指定标签的键，返回删除的数量
+func (c *baseCache[K, V]) InvalidateTag(tags ...string) int {
+	c.mu.Lock()
+	defer c.unlockAndNotify()
+	if c.closed.Load() {
+		return 0
+	}
+	var keys []K
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			c.remove(key, EvictDeleted)
+			keys = append(keys, key)
+		}
+	}
+	// 与 Del 一致，清除缓存的加载错误
+	c.loader.forget(keys...)
+	return len(keys)
+}
+
+// 删除以 prefix 开头的键，返回删除的数量，仅对字符串类型的键生效
+func (c *baseCache[K, V]) DelPrefix(prefix string) int {
+	return c.delWhere(func(key string) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+}
+
+// 删除整个键符合 pattern 的键，* 匹配任意字符序列，规则见 strx.LikeFunc，返回删除的数量，仅对字符串类型的键生效
+func (c *baseCache[K, V]) DelMatch(pattern string) (int, error) {
+	match, err := strx.LikeFunc(pattern)
+	if err != nil {
+		return 0, err
+	}
+	return c.delWhere(match), nil
+}
+
+func (c *baseCache[K, V]) delWhere(match func(key string) bool) int {
+	c.mu.Lock()
+	defer c.unlockAndNotify()
+	if c.closed.Load() {
+		return 0
+	}
+	var keys []K
+	for key := range c.cache {
+		if s, ok := any(key).(string); ok && match(s) {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		c.remove(key, EvictDeleted)
+	}
+	// 与 Del 一致，清除缓存的加载错误，只缓存了错误的键不在 c.cache 中，需要单独匹配
+	c.loader.forgetWhere(func(key K) bool {
+		s, ok := any(key).(string)
+		return ok && match(s)
+	})
+	return len(keys)
+}
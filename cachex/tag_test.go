@@ -0,0 +1,108 @@
+package cachex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// 测试：按标签批量删除
+func TestInvalidateTag(t *testing.T) {
+	cache := NewBaseCache[string, int](CacheOption{})
+	defer cache.Destroy()
+
+	cache.SetWithTags("u1", 1, 0, "tenant:42", "user")
+	cache.SetWithTags("u2", 2, 0, "tenant:42")
+	cache.SetWithTags("u3", 3, 0, "tenant:7", "user")
+	// 重新写入后标签以最后一次为准
+	cache.SetWithTags("u2", 2, 0, "tenant:7")
+
+	if n := cache.InvalidateTag("tenant:42"); n != 1 {
+		t.Fatalf("want 1 invalidated, got %d", n)
+	}
+	if _, ok := cache.Get("u1"); ok {
+		t.Fatalf("want u1 invalidated")
+	}
+	if n := cache.InvalidateTag("user", "tenant:7"); n != 2 {
+		t.Fatalf("want 2 invalidated, got %d", n)
+	}
+	if cache.Len() != 0 || len(cache.tags) != 0 {
+		t.Fatalf("want empty cache and tags, got %d %v", cache.Len(), cache.tags)
+	}
+}
+
+// 测试：按前缀和通配符删除
+func TestDelPrefixMatch(t *testing.T) {
+	cache := NewShardedCache[string, int](CacheOption{Shards: 4})
+	defer cache.Destroy()
+
+	for _, key := range []string{"tenant:42:a", "tenant:42:b", "tenant:420:a", "tenant:7:a", "other"} {
+		cache.Set(key, 1)
+	}
+	if n := cache.DelPrefix("tenant:42:"); n != 2 {
+		t.Fatalf("want 2 deleted, got %d", n)
+	}
+	if n, err := cache.DelMatch("tenant:*:a"); err != nil || n != 2 {
+		t.Fatalf("want 2 deleted, got %d %v", n, err)
+	}
+	if n, err := cache.DelMatch("OTHER"); err != nil || n != 1 {
+		t.Fatalf("want 1 deleted, got %d %v", n, err)
+	}
+	if cache.Len() != 0 {
+		t.Fatalf("want empty, got %d", cache.Len())
+	}
+}
+
+// 测试：通配符整串匹配，其他字符按字面处理
+func TestDelMatchAnchored(t *testing.T) {
+	cache := NewBaseCache[string, int](CacheOption{})
+	defer cache.Destroy()
+
+	for _, key := range []string{"user:1", "superuser:1", "x[1", "x1"} {
+		cache.Set(key, 1)
+	}
+	if n, err := cache.DelMatch("user:*"); err != nil || n != 1 {
+		t.Fatalf("want 1 deleted, got %d %v", n, err)
+	}
+	if _, ok := cache.Get("superuser:1"); !ok {
+		t.Fatalf("want superuser:1 kept")
+	}
+	if n, err := cache.DelMatch("x[*"); err != nil || n != 1 {
+		t.Fatalf("want 1 deleted, got %d %v", n, err)
+	}
+	if _, ok := cache.Get("x1"); !ok {
+		t.Fatalf("want x1 kept")
+	}
+}
+
+// 测试：批量删除与 Del 一样清除缓存的加载错误
+func TestBulkInvalidateForgetsLoadError(t *testing.T) {
+	cache := NewBaseCache[string, int](CacheOption{ErrorExpire: time.Minute})
+	defer cache.Destroy()
+
+	fail := func(ctx context.Context) (int, time.Duration, error) {
+		return 0, 0, errors.New("boom")
+	}
+	ok := func(ctx context.Context) (int, time.Duration, error) {
+		return 1, 0, nil
+	}
+	for _, invalidate := range []func(){
+		func() { cache.DelPrefix("k") },
+		func() { cache.DelMatch("k*") },
+		func() {
+			cache.SetWithTags("k", 0, time.Minute, "t")
+			cache.InvalidateTag("t")
+		},
+	} {
+		cache.GetOrLoad("k", fail)
+		if _, err := cache.GetOrLoad("k", ok); err == nil {
+			t.Fatalf("want cached error")
+		}
+		invalidate()
+		if v, err := cache.GetOrLoad("k", ok); err != nil || v != 1 {
+			t.Fatalf("want 1, got %d %v", v, err)
+		}
+		cache.Del("k")
+	}
+}
@@ -0,0 +1,257 @@
+package cachex
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 二级缓存的读写模式，三种模式下读取时一级缓存未命中都会回源到二级缓存
+type TieredMode int
+
+const (
+	// 只读二级缓存，写入和删除只作用于一级缓存
+	ReadThrough TieredMode = iota
+	// 同步写入二级缓存
+	WriteThrough
+	// 异步写入二级缓存，写入和删除按顺序在后台执行
+	WriteBehind
+)
+
+type TieredOption[K comparable] struct {
+	Mode TieredMode
+	// 值的编码方式，默认 GobCodec
+	Codec Codec
+	// 将键转换为后端使用的字符串，默认使用 fmt.Sprint
+	KeyFunc func(K) string
+	// 写入二级缓存时的默认过期时间，小于等于0时永不过期
+	DefaultExpire time.Duration
+	// 从二级缓存回填到一级缓存时的过期时间，小于等于0时使用一级缓存的默认值
+	FillExpire time.Duration
+	// WriteBehind 模式下的队列长度，默认1024，队列满时写入会阻塞
+	QueueSize int
+	// 二级缓存出错时的回调，没有返回值的方法通过它暴露错误
+	OnError func(err error)
+}
+
+type tieredOp struct {
+	key  string
+	data []byte
+	ttl  time.Duration
+	del  bool
+}
+
+// 一级内存缓存加二级可插拔后端的组合缓存
+type tieredCache[K comparable, V any] struct {
+	l1    Cache[K, V]
+	l2    Backend
+	opts  TieredOption[K]
+	queue chan tieredOp
+	// 保护 queue 的发送与关闭，关闭后不再写入二级缓存
+	qmu    sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+	once   sync.Once
+}
+
+func NewTieredCache[K comparable, V any](l1 Cache[K, V], l2 Backend, opts TieredOption[K]) *tieredCache[K, V] {
+	if opts.Codec == nil {
+		opts.Codec = GobCodec
+	}
+	if opts.KeyFunc == nil {
+		opts.KeyFunc = func(key K) string {
+			return fmt.Sprint(key)
+		}
+	}
+	c := &tieredCache[K, V]{
+		l1:   l1,
+		l2:   l2,
+		opts: opts,
+	}
+	if opts.Mode == WriteBehind {
+		size := opts.QueueSize
+		if size <= 0 {
+			size = 1024
+		}
+		c.queue = make(chan tieredOp, size)
+		c.wg.Add(1)
+		go c.flush()
+	}
+	return c
+}
+
+func (c *tieredCache[K, V]) flush() {
+	defer c.wg.Done()
+	for op := range c.queue {
+		c.apply(op)
+	}
+}
+
+func (c *tieredCache[K, V]) apply(op tieredOp) {
+	var err error
+	if op.del {
+		err = c.l2.Del(op.key)
+	} else {
+		err = c.l2.Set(op.key, op.data, op.ttl)
+	}
+	c.report(err)
+}
+
+// 关闭后的写入直接丢弃，不视为错误
+func (c *tieredCache[K, V]) report(err error) {
+	if err != nil && err != ErrClosed && c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
+
+func (c *tieredCache[K, V]) fetch(key K) (V, bool, error) {
+	var value V
+	data, ok, err := c.l2.Get(c.opts.KeyFunc(key))
+	if err != nil || !ok {
+		return value, false, err
+	}
+	if err = c.opts.Codec.Unmarshal(data, &value); err != nil {
+		return value, false, err
+	}
+	return value, true, nil
+}
+
+// 从二级缓存读取，命中时回填一级缓存
+func (c *tieredCache[K, V]) load(key K) (V, bool, error) {
+	value, ok, err := c.fetch(key)
+	if !ok {
+		return value, false, err
+	}
+	if c.opts.FillExpire > 0 {
+		c.l1.SetExpire(key, value, c.opts.FillExpire)
+	} else {
+		c.l1.Set(key, value)
+	}
+	return value, true, nil
+}
+
+func (c *tieredCache[K, V]) store(key K, value V, ttl time.Duration) error {
+	if c.opts.Mode == ReadThrough {
+		return nil
+	}
+	data, err := c.opts.Codec.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.send(tieredOp{key: c.opts.KeyFunc(key), data: data, ttl: ttl})
+}
+
+// WriteBehind 模式下放入队列，其余模式同步执行
+func (c *tieredCache[K, V]) send(op tieredOp) error {
+	c.qmu.RLock()
+	defer c.qmu.RUnlock()
+	if c.closed {
+		return ErrClosed
+	}
+	if c.opts.Mode == WriteBehind {
+		c.queue <- op
+		return nil
+	}
+	if op.del {
+		return c.l2.Del(op.key)
+	}
+	return c.l2.Set(op.key, op.data, op.ttl)
+}
+
+func (c *tieredCache[K, V]) Get(key K) (V, bool) {
+	if value, ok := c.l1.Get(key); ok {
+		return value, true
+	}
+	value, ok, err := c.load(key)
+	c.report(err)
+	return value, ok
+}
+
+func (c *tieredCache[K, V]) Gets(keys ...K) []V {
+	values := make([]V, 0, len(keys))
+	for _, key := range keys {
+		if value, ok := c.Get(key); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (c *tieredCache[K, V]) Set(key K, value V) {
+	c.l1.Set(key, value)
+	c.report(c.store(key, value, c.opts.DefaultExpire))
+}
+
+func (c *tieredCache[K, V]) SetExpire(key K, value V, expire time.Duration) {
+	c.l1.SetExpire(key, value, expire)
+	c.report(c.store(key, value, expire))
+}
+
+// 删除一级缓存中的键，并同步或异步删除二级缓存中的键
+// ReadThrough 模式下二级缓存归其他服务所有，只删除一级缓存
+func (c *tieredCache[K, V]) Del(key ...K) {
+	c.l1.Del(key...)
+	if c.opts.Mode == ReadThrough {
+		return
+	}
+	for _, k := range key {
+		c.report(c.send(tieredOp{key: c.opts.KeyFunc(k), del: true}))
+	}
+}
+
+// 只清空一级缓存，后端的数据不受影响
+func (c *tieredCache[K, V]) Clear() {
+	c.l1.Clear()
+}
+
+func (c *tieredCache[K, V]) Destroy() {
+	c.Close()
+}
+
+// 等待 WriteBehind 队列写完后关闭一级缓存，可以重复调用
+func (c *tieredCache[K, V]) Close() error {
+	c.once.Do(func() {
+		c.qmu.Lock()
+		c.closed = true
+		if c.queue != nil {
+			close(c.queue)
+		}
+		c.qmu.Unlock()
+		c.wg.Wait()
+		c.l1.Close()
+	})
+	return nil
+}
+
+func (c *tieredCache[K, V]) GetOrSetFunc(key K, fn func() V) V {
+	value, err := c.GetOrLoad(key, func(ctx context.Context) (V, time.Duration, error) {
+		return fn(), 0, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// 依次查找一级缓存、二级缓存，都未命中时调用 fn 加载并按模式写入二级缓存
+// 同一个键的并发加载由一级缓存合并
+func (c *tieredCache[K, V]) GetOrLoad(key K, fn LoadFunc[V]) (V, error) {
+	return c.l1.GetOrLoad(key, func(ctx context.Context) (V, time.Duration, error) {
+		value, ok, err := c.fetch(key)
+		c.report(err)
+		if ok {
+			return value, c.opts.FillExpire, nil
+		}
+		value, ttl, err := fn(ctx)
+		if err != nil {
+			return value, ttl, err
+		}
+		l2ttl := ttl
+		if l2ttl == 0 {
+			l2ttl = c.opts.DefaultExpire
+		}
+		c.report(c.store(key, value, l2ttl))
+		return value, ttl, nil
+	})
+}
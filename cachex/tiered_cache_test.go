@@ -0,0 +1,106 @@
+package cachex
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var _ Cache[string, int] = (*tieredCache[string, int])(nil)
+
+func newTestBackend(t *testing.T) *fileBackend {
+	backend, err := NewFileBackend(t.TempDir())
+	if err != nil {
+		t.Fatalf("create backend failed: %v", err)
+	}
+	return backend
+}
+
+// 测试：同步写入后，新的一级缓存可以从二级缓存读到
+func TestTieredWriteThrough(t *testing.T) {
+	backend := newTestBackend(t)
+	opts := TieredOption[string]{Mode: WriteThrough, OnError: func(err error) {
+		t.Errorf("backend error: %v", err)
+	}}
+
+	c1 := NewTieredCache[string, int](NewBaseCache[string, int](CacheOption{}), backend, opts)
+	c1.Set("a", 1)
+	c1.Destroy()
+
+	c2 := NewTieredCache[string, int](NewBaseCache[string, int](CacheOption{}), backend, opts)
+	defer c2.Destroy()
+	if v, ok := c2.Get("a"); !ok || v != 1 {
+		t.Fatalf("want a=1 from l2, got %v %v", v, ok)
+	}
+
+	// 删除同时作用于两级
+	c2.Del("a")
+	if _, ok := c2.Get("a"); ok {
+		t.Fatalf("want a deleted")
+	}
+	if _, ok, _ := backend.Get("a"); ok {
+		t.Fatalf("want a deleted in backend")
+	}
+}
+
+// 测试：异步写入在销毁时全部落盘
+func TestTieredWriteBehind(t *testing.T) {
+	backend := newTestBackend(t)
+	c := NewTieredCache[int, string](NewBaseCache[int, string](CacheOption{}), backend, TieredOption[int]{Mode: WriteBehind})
+	for i := 0; i < 100; i++ {
+		c.Set(i, "v")
+	}
+	c.Del(0)
+	c.Destroy()
+
+	for i := 1; i < 100; i++ {
+		if _, ok, _ := backend.Get(strconv.Itoa(i)); !ok {
+			t.Fatalf("want %d written", i)
+		}
+	}
+	if _, ok, _ := backend.Get("0"); ok {
+		t.Fatalf("want 0 deleted")
+	}
+}
+
+// 测试：只读模式不写入后端，加载时先查后端
+func TestTieredReadThrough(t *testing.T) {
+	backend := newTestBackend(t)
+	data, _ := GobCodec.Marshal(42)
+	backend.Set("shared", data, time.Minute)
+
+	c := NewTieredCache[string, int](NewBaseCache[string, int](CacheOption{}), backend, TieredOption[string]{})
+	defer c.Destroy()
+
+	c.Set("local", 1)
+	if _, ok, _ := backend.Get("local"); ok {
+		t.Fatalf("want local not written to backend")
+	}
+	v, err := c.GetOrLoad("shared", func(ctx context.Context) (int, time.Duration, error) {
+		t.Fatalf("want value from backend")
+		return 0, 0, nil
+	})
+	if err != nil || v != 42 {
+		t.Fatalf("want 42, got %v %v", v, err)
+	}
+
+	// 删除只作用于一级缓存，二级缓存的数据保留
+	c.Del("shared")
+	if _, ok, _ := backend.Get("shared"); !ok {
+		t.Fatalf("want shared kept in backend")
+	}
+}
+
+// 测试：文件后端的过期
+func TestFileBackendExpire(t *testing.T) {
+	backend := newTestBackend(t)
+	backend.Set("a", []byte("1"), 10*time.Millisecond)
+	if _, ok, _ := backend.Get("a"); !ok {
+		t.Fatalf("want a")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, ok, _ := backend.Get("a"); ok {
+		t.Fatalf("want a expired")
+	}
+}
@@ -0,0 +1,116 @@
+package cachex
+
+import (
+	"reflect"
+)
+
+// 是否设置了容量上限，有上限时需要带淘汰策略的缓存
+func (o CacheOption) bounded() bool {
+	return o.MaxEntries > 0 || o.MaxWeight > 0
+}
+
+// 设置计算条目权重的函数，需要在写入前调用，已有条目的权重不会重新计算
+// 传入nil时恢复默认，设置了 MaxWeight 时按键值占用的内存估算，否则权重为0
+func (c *baseCache[K, V]) SetWeigher(fn func(key K, value V) int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if fn == nil && c.opts.MaxWeight > 0 {
+		fn = defaultWeigher[K, V]
+	}
+	c.weigher = fn
+}
+
+// 默认的权重，按键和值占用的内存估算，单位为字节
+func defaultWeigher[K comparable, V any](key K, value V) int64 {
+	return sizeOf(&key) + sizeOf(&value)
+}
+
+// 估算 ptr 指向的值占用的内存，包括切片、map、指针等间接引用的部分
+func sizeOf(ptr any) int64 {
+	switch v := ptr.(type) {
+	case *string:
+		return int64(reflect.TypeOf(*v).Size()) + int64(len(*v))
+	case *[]byte:
+		return int64(reflect.TypeOf(*v).Size()) + int64(cap(*v))
+	}
+	v := reflect.ValueOf(ptr).Elem()
+	return int64(v.Type().Size()) + indirectSize(v, make(map[uintptr]struct{}))
+}
+
+// v 引用的、不在 v 自身内存中的部分，seen 用于跳过重复引用和循环引用
+func indirectSize(v reflect.Value, seen map[uintptr]struct{}) int64 {
+	switch v.Kind() {
+	case reflect.String:
+		return int64(v.Len())
+	case reflect.Slice:
+		if v.IsNil() || visited(v.Pointer(), seen) {
+			return 0
+		}
+		size := int64(v.Cap()) * int64(v.Type().Elem().Size())
+		if hasIndirect(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				size += indirectSize(v.Index(i), seen)
+			}
+		}
+		return size
+	case reflect.Array:
+		var size int64
+		if hasIndirect(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				size += indirectSize(v.Index(i), seen)
+			}
+		}
+		return size
+	case reflect.Map:
+		if v.IsNil() || visited(v.Pointer(), seen) {
+			return 0
+		}
+		entry := int64(v.Type().Key().Size() + v.Type().Elem().Size())
+		size := int64(v.Len()) * entry
+		iter := v.MapRange()
+		for iter.Next() {
+			size += indirectSize(iter.Key(), seen) + indirectSize(iter.Value(), seen)
+		}
+		return size
+	case reflect.Pointer:
+		if v.IsNil() || visited(v.Pointer(), seen) {
+			return 0
+		}
+		elem := v.Elem()
+		return int64(elem.Type().Size()) + indirectSize(elem, seen)
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		elem := v.Elem()
+		return int64(elem.Type().Size()) + indirectSize(elem, seen)
+	case reflect.Struct:
+		var size int64
+		for i := 0; i < v.NumField(); i++ {
+			size += indirectSize(v.Field(i), seen)
+		}
+		return size
+	}
+	return 0
+}
+
+func visited(p uintptr, seen map[uintptr]struct{}) bool {
+	if _, ok := seen[p]; ok {
+		return true
+	}
+	seen[p] = struct{}{}
+	return false
+}
+
+// 元素是否可能引用额外的内存，数值类型的切片不需要逐个计算
+func hasIndirect(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Array:
+		return hasIndirect(t.Elem())
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package cachex
+
+import (
+	"testing"
+)
+
+// 测试：按 SetWeigher 设置的函数计算的权重淘汰，Stats 返回当前总权重
+func TestWeigherEvict(t *testing.T) {
+	cache := NewLRUCache[string, string](CacheOption{MaxWeight: 10})
+	defer cache.Destroy()
+	cache.SetWeigher(func(key string, value string) int64 {
+		return int64(len(value))
+	})
+
+	cache.Set("a", "aaaa")
+	cache.Set("b", "bbbb")
+	if w := cache.Stats().Weight; w != 8 {
+		t.Fatalf("want weight 8, got %d", w)
+	}
+	cache.Set("c", "cccc")
+	if _, ok := cache.Get("a"); ok {
+		t.Fatalf("want a evicted")
+	}
+	if w := cache.Stats().Weight; w != 8 {
+		t.Fatalf("want weight 8, got %d", w)
+	}
+
+	// 覆盖时按新值重新计算
+	cache.Set("b", "b")
+	if w := cache.Stats().Weight; w != 5 {
+		t.Fatalf("want weight 5, got %d", w)
+	}
+	cache.Del("b", "c")
+	if w := cache.Stats().Weight; w != 0 {
+		t.Fatalf("want weight 0, got %d", w)
+	}
+}
+
+// 测试：未设置权重函数时按内存估算权重
+func TestDefaultWeigher(t *testing.T) {
+	cache := NewLRUCache[int, []byte](CacheOption{MaxWeight: 3000})
+	defer cache.Destroy()
+
+	for i := 0; i < 10; i++ {
+		cache.Set(i, make([]byte, 1000))
+	}
+	if n := cache.Len(); n != 2 {
+		t.Fatalf("want 2 entries, got %d", n)
+	}
+	if w := cache.Stats().Weight; w <= 2000 || w > 3000 {
+		t.Fatalf("want weight in (2000, 3000], got %d", w)
+	}
+}
+
+type sizeNode struct {
+	name string
+	next *sizeNode
+}
+
+// 测试：估算字符串、切片、map、循环引用的大小
+func TestSizeOf(t *testing.T) {
+	s := "hello"
+	if n := sizeOf(&s); n != 16+5 {
+		t.Fatalf("want 21, got %d", n)
+	}
+	list := []string{"ab", "cd"}
+	if n := sizeOf(&list); n != 24+2*16+4 {
+		t.Fatalf("want 60, got %d", n)
+	}
+	m := map[string]int{"a": 1}
+	if n := sizeOf(&m); n < 8+16+8+1 {
+		t.Fatalf("want at least 33, got %d", n)
+	}
+	node := &sizeNode{name: "a"}
+	node.next = node
+	if n := sizeOf(&node); n != 8+24+1 {
+		t.Fatalf("want 33, got %d", n)
+	}
+}
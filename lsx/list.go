@@ -3,24 +3,57 @@ package lsx
 import (
 	// "reflect"
 
+	"context"
 	"fmt"
 	"reflect"
 	"runtime"
+	"sync"
 
 	"github.com/llyb120/yoya/internal"
 	"github.com/llyb120/yoya/stlx"
 	"github.com/llyb120/yoya/syncx"
 )
 
-type lsxOption int
+type lsxOption interface {
+	apply(ctx *lsxOptionContext)
+}
+
+type lsxFlag int
 
 const (
-	IgnoreNil lsxOption = iota
+	IgnoreNil lsxFlag = iota
 	IgnoreEmpty
 	Async
 	DoDistinct
 )
 
+func (f lsxFlag) apply(ctx *lsxOptionContext) {
+	switch f {
+	case IgnoreNil:
+		ctx.ignoreNil = true
+	case IgnoreEmpty:
+		ctx.ignoreEmpty = true
+	case Async:
+		ctx.async = true
+	case DoDistinct:
+		ctx.distinct = true
+	}
+}
+
+type executorOption struct {
+	executor syncx.Executor
+}
+
+func (o executorOption) apply(ctx *lsxOptionContext) {
+	ctx.async = true
+	ctx.executor = o.executor
+}
+
+// 使用指定的执行器异步执行，等同于 Async 且任务交给执行器运行
+func WithExecutor(e syncx.Executor) lsxOption {
+	return executorOption{e}
+}
+
 var zero = &struct {
 	bool
 	_ struct{}
@@ -31,21 +64,13 @@ type lsxOptionContext struct {
 	ignoreEmpty bool
 	async       bool
 	distinct    bool
+	executor    syncx.Executor
 }
 
 func scanOptions(opts []lsxOption) *lsxOptionContext {
 	ctx := &lsxOptionContext{}
 	for _, opt := range opts {
-		switch opt {
-		case IgnoreNil:
-			ctx.ignoreNil = true
-		case IgnoreEmpty:
-			ctx.ignoreEmpty = true
-		case Async:
-			ctx.async = true
-		case DoDistinct:
-			ctx.distinct = true
-		}
+		opt.apply(ctx)
 	}
 	return ctx
 }
@@ -54,12 +79,23 @@ func Map[T any, R any](arr []T, fn func(T, int) R, opts ...lsxOption) []R {
 	ctx := scanOptions(opts)
 	result := make([]R, len(arr))
 	if ctx.async {
-		var wg = internal.NewThreadPool(runtime.GOMAXPROCS(0))
-		defer wg.Destroy()
+		executor := ctx.executor
+		if executor == nil {
+			executor = syncx.NewFixedExecutor(syncx.ExecutorOption{
+				Workers:   runtime.GOMAXPROCS(0),
+				QueueSize: runtime.GOMAXPROCS(0),
+				Reject:    syncx.RejectBlock,
+			})
+			defer executor.Shutdown(context.Background())
+		}
+		var wg sync.WaitGroup
+		var mu sync.Mutex
 		for i, v := range arr {
 			i := i
 			v := v
-			wg.Go(func() {
+			wg.Add(1)
+			task := func() {
+				defer wg.Done()
 				r := fn(v, i)
 				// 只有指针才可以等待
 				if reflect.TypeOf(r).Kind() == reflect.Ptr {
@@ -68,10 +104,14 @@ func Map[T any, R any](arr []T, fn func(T, int) R, opts ...lsxOption) []R {
 						return
 					}
 				}
-				wg.Lock()
-				defer wg.Unlock()
+				mu.Lock()
+				defer mu.Unlock()
 				result[i] = r
-			})
+			}
+			// 执行器拒绝时在当前协程中执行
+			if err := executor.Submit(task); err != nil {
+				task()
+			}
 		}
 		wg.Wait()
 	} else {
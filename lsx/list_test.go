@@ -1,9 +1,12 @@
 package lsx
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
+
+	"github.com/llyb120/yoya/syncx"
 )
 
 func TestMap(t *testing.T) {
@@ -139,3 +142,26 @@ func TestGroup(t *testing.T) {
 	})
 	fmt.Println(result)
 }
+
+func TestMapExecutor(t *testing.T) {
+	e := syncx.NewFixedExecutor(syncx.ExecutorOption{Workers: 2, QueueSize: 2, Reject: syncx.RejectCallerRuns})
+	defer e.Shutdown(context.Background())
+	arr := []int{1, 2, 3, 4, 5}
+	result := Map(arr, func(v int, i int) int {
+		return v * 2
+	}, WithExecutor(e))
+	for i, v := range result {
+		if v != arr[i]*2 {
+			t.Errorf("want %d, got %d", arr[i]*2, v)
+		}
+	}
+	if m := e.Metrics(); m.Submitted != 5 {
+		t.Errorf("want 5 submitted, got %d", m.Submitted)
+	}
+	result = Map(arr, func(v int, i int) int {
+		return v + 1
+	}, Async)
+	if result[4] != 6 {
+		t.Errorf("want 6, got %d", result[4])
+	}
+}
@@ -1,8 +1,10 @@
 package objx
 
 import (
+	"context"
 	"reflect"
 	"runtime"
+	"sync"
 
 	"github.com/llyb120/yoya/internal"
 	"github.com/llyb120/yoya/syncx"
@@ -26,13 +28,40 @@ var BreakWalkSelf = &walkCommand{}
 // type walkFunc = func(s any, k any, v any) any
 // type asyncWalkFunc = func(s any, k any, v any) syncx.AsyncFn
 
-type walkOption int
+type walkOption interface {
+	apply(ctx *walkContext)
+}
+
+type walkFlag int
 
 var (
-	Async walkOption = -1
-	Level walkOption = 1
+	Async walkFlag = -1
+	Level walkFlag = 1
 )
 
+func (f walkFlag) apply(ctx *walkContext) {
+	if f == Async {
+		ctx.isAsync = true
+	}
+	if f > 0 {
+		ctx.level = int(f)
+	}
+}
+
+type executorOption struct {
+	executor syncx.Executor
+}
+
+func (o executorOption) apply(ctx *walkContext) {
+	ctx.isAsync = true
+	ctx.executor = o.executor
+}
+
+// 使用指定的执行器异步遍历，等同于 Async 且任务交给执行器运行
+func WithExecutor(e syncx.Executor) walkOption {
+	return executorOption{e}
+}
+
 // 遍历任意对象
 // 因为map和字段的问题，遍历的顺序无法预测，但从外到内可以保证(先序遍历)
 //
@@ -60,16 +89,15 @@ func Walk(dest any, fn func(s any, k any, v any) any, opts ...walkOption) {
 		fn: fn,
 	}
 	for _, opt := range opts {
-		if opt == Async {
-			walkCtx.isAsync = true
-		}
-		if opt > 0 {
-			walkCtx.level = int(opt)
-		}
+		opt.apply(walkCtx)
 	}
-	if walkCtx.isAsync {
-		walkCtx.wg = internal.NewThreadPool(runtime.GOMAXPROCS(0))
-		defer walkCtx.wg.Destroy()
+	if walkCtx.isAsync && walkCtx.executor == nil {
+		walkCtx.executor = syncx.NewFixedExecutor(syncx.ExecutorOption{
+			Workers:   runtime.GOMAXPROCS(0),
+			QueueSize: runtime.GOMAXPROCS(0),
+			Reject:    syncx.RejectBlock,
+		})
+		defer walkCtx.executor.Shutdown(context.Background())
 	}
 	walkCtx.walk(dest, 0)
 	if walkCtx.isAsync {
@@ -78,10 +106,12 @@ func Walk(dest any, fn func(s any, k any, v any) any, opts ...walkOption) {
 }
 
 type walkContext struct {
-	level   int
-	isAsync bool
-	wg      *internal.ThreadPool
-	fn      func(s any, k any, v any) any
+	level    int
+	isAsync  bool
+	executor syncx.Executor
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	fn       func(s any, k any, v any) any
 }
 
 func (w *walkContext) doFunc(ref reflect.Value, k any, v reflect.Value) any {
@@ -90,18 +120,24 @@ func (w *walkContext) doFunc(ref reflect.Value, k any, v reflect.Value) any {
 		k = kk.Interface()
 	}
 	if w.isAsync {
-		w.wg.Go(func() {
-			res = w.fn(ref.Interface(), k, v.Interface())
+		w.wg.Add(1)
+		task := func() {
+			defer w.wg.Done()
+			res := w.fn(ref.Interface(), k, v.Interface())
 			err := syncx.Await(res)
 			if err != nil {
 				return
 			}
 			if res != Unchanged && res != nil {
-				w.wg.Lock()
-				defer w.wg.Unlock()
+				w.mu.Lock()
+				defer w.mu.Unlock()
 				internal.UnsafeSetFieldValue(v, reflect.ValueOf(res).Elem(), true)
 			}
-		})
+		}
+		// 执行器拒绝时在当前协程中执行
+		if err := w.executor.Submit(task); err != nil {
+			task()
+		}
 	} else {
 		res = w.fn(ref.Interface(), k, v.Interface())
 		if res != Unchanged && res != nil {
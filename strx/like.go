@@ -58,6 +58,26 @@ func like[T string | LikeType](str string, pattern T) bool {
 	return false
 }
 
+// 预编译 pattern，返回整串匹配的函数，适合用同一规则匹配大量字符串
+// * 匹配任意字符序列，其余字符按字面匹配，不区分大小写
+// 与 Like 不同，不会去掉首尾空格，也不会把 pattern 中的其他字符当作正则表达式
+func LikeFunc(pattern string) (func(str string) bool, error) {
+	if !strings.Contains(pattern, "*") {
+		return func(str string) bool {
+			return strings.EqualFold(str, pattern)
+		}, nil
+	}
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	re, err := regexp.Compile("(?is)^" + strings.Join(parts, ".*") + "$")
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString, nil
+}
+
 func isNumber(str string) bool {
 	return regexp.MustCompile(`^\d+$`).MatchString(str)
 }
@@ -61,3 +61,32 @@ func TestLike(t *testing.T) {
 		fmt.Println("**", v2, "**")
 	}
 }
+
+func TestLikeFunc(t *testing.T) {
+	for _, tt := range []struct {
+		str     string
+		pattern string
+		want    bool
+	}{
+		{"hello", "hello", true},
+		{"HELLO", "hello", true},
+		{"hello world", "hello*", true},
+		{"hi world", "hello*", false},
+		{"tenant:42:user", "tenant:42:*", true},
+		// 整串匹配
+		{"superuser:1", "user:*", false},
+		{"user:1:profile", "user:*", true},
+		// 其他字符按字面匹配
+		{"x[1", "x[*", true},
+		{"a.c", "a.*", true},
+		{"abc", "a.*", false},
+	} {
+		match, err := LikeFunc(tt.pattern)
+		if err != nil {
+			t.Fatalf("LikeFunc(%q): %v", tt.pattern, err)
+		}
+		if got := match(tt.str); got != tt.want {
+			t.Errorf("LikeFunc(%q)(%q) = %v, want %v", tt.pattern, tt.str, got, tt.want)
+		}
+	}
+}
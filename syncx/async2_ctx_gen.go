@@ -0,0 +1,1070 @@
+// Code generated by gen_async.go; DO NOT EDIT.
+
+package syncx
+
+import (
+	"context"
+	"sync"
+)
+
+func Async2Ctx_0_0(fn func(context.Context)) func(context.Context) Future[any] {
+	return func(ctx context.Context) Future[any] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer handlePanic()
+			runCtx(ctx, func(ctx context.Context) {
+				fn(ctx)
+			})
+		}()
+		return func() any {
+			wg.Wait()
+			return nil
+		}
+	}
+}
+
+func Async2Ctx_0_1[R0 any](fn func(context.Context) R0) func(context.Context) Future[R0] {
+	return func(ctx context.Context) Future[R0] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0)
+			runCtx(ctx, func(ctx context.Context) {
+				r0 = fn(ctx)
+			}, &r0)
+		}()
+		return func() R0 {
+			wg.Wait()
+			return r0
+		}
+	}
+}
+
+func Async2Ctx_0_2[R0 any, R1 any](fn func(context.Context) (R0, R1)) func(context.Context) (Future[R0], Future[R1]) {
+	return func(ctx context.Context) (Future[R0], Future[R1]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1 = fn(ctx)
+			}, &r0, &r1)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}
+	}
+}
+
+func Async2Ctx_0_3[R0 any, R1 any, R2 any](fn func(context.Context) (R0, R1, R2)) func(context.Context) (Future[R0], Future[R1], Future[R2]) {
+	return func(ctx context.Context) (Future[R0], Future[R1], Future[R2]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2 = fn(ctx)
+			}, &r0, &r1, &r2)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}
+	}
+}
+
+func Async2Ctx_0_4[R0 any, R1 any, R2 any, R3 any](fn func(context.Context) (R0, R1, R2, R3)) func(context.Context) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+	return func(ctx context.Context) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		var r3 R3
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2, &r3)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2, r3 = fn(ctx)
+			}, &r0, &r1, &r2, &r3)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}, func() R3 {
+				wg.Wait()
+				return r3
+			}
+	}
+}
+
+func Async2Ctx_1_0[P0 any](fn func(context.Context, P0)) func(context.Context, P0) Future[any] {
+	return func(ctx context.Context, p0 P0) Future[any] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer handlePanic()
+			runCtx(ctx, func(ctx context.Context) {
+				fn(ctx, p0)
+			})
+		}()
+		return func() any {
+			wg.Wait()
+			return nil
+		}
+	}
+}
+
+func Async2Ctx_1_1[P0, R0 any](fn func(context.Context, P0) R0) func(context.Context, P0) Future[R0] {
+	return func(ctx context.Context, p0 P0) Future[R0] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0)
+			runCtx(ctx, func(ctx context.Context) {
+				r0 = fn(ctx, p0)
+			}, &r0)
+		}()
+		return func() R0 {
+			wg.Wait()
+			return r0
+		}
+	}
+}
+
+func Async2Ctx_1_2[P0, R0 any, R1 any](fn func(context.Context, P0) (R0, R1)) func(context.Context, P0) (Future[R0], Future[R1]) {
+	return func(ctx context.Context, p0 P0) (Future[R0], Future[R1]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1 = fn(ctx, p0)
+			}, &r0, &r1)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}
+	}
+}
+
+func Async2Ctx_1_3[P0, R0 any, R1 any, R2 any](fn func(context.Context, P0) (R0, R1, R2)) func(context.Context, P0) (Future[R0], Future[R1], Future[R2]) {
+	return func(ctx context.Context, p0 P0) (Future[R0], Future[R1], Future[R2]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2 = fn(ctx, p0)
+			}, &r0, &r1, &r2)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}
+	}
+}
+
+func Async2Ctx_1_4[P0, R0 any, R1 any, R2 any, R3 any](fn func(context.Context, P0) (R0, R1, R2, R3)) func(context.Context, P0) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+	return func(ctx context.Context, p0 P0) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		var r3 R3
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2, &r3)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2, r3 = fn(ctx, p0)
+			}, &r0, &r1, &r2, &r3)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}, func() R3 {
+				wg.Wait()
+				return r3
+			}
+	}
+}
+
+func Async2Ctx_2_0[P0, P1 any](fn func(context.Context, P0, P1)) func(context.Context, P0, P1) Future[any] {
+	return func(ctx context.Context, p0 P0, p1 P1) Future[any] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer handlePanic()
+			runCtx(ctx, func(ctx context.Context) {
+				fn(ctx, p0, p1)
+			})
+		}()
+		return func() any {
+			wg.Wait()
+			return nil
+		}
+	}
+}
+
+func Async2Ctx_2_1[P0, P1, R0 any](fn func(context.Context, P0, P1) R0) func(context.Context, P0, P1) Future[R0] {
+	return func(ctx context.Context, p0 P0, p1 P1) Future[R0] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0)
+			runCtx(ctx, func(ctx context.Context) {
+				r0 = fn(ctx, p0, p1)
+			}, &r0)
+		}()
+		return func() R0 {
+			wg.Wait()
+			return r0
+		}
+	}
+}
+
+func Async2Ctx_2_2[P0, P1, R0 any, R1 any](fn func(context.Context, P0, P1) (R0, R1)) func(context.Context, P0, P1) (Future[R0], Future[R1]) {
+	return func(ctx context.Context, p0 P0, p1 P1) (Future[R0], Future[R1]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1 = fn(ctx, p0, p1)
+			}, &r0, &r1)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}
+	}
+}
+
+func Async2Ctx_2_3[P0, P1, R0 any, R1 any, R2 any](fn func(context.Context, P0, P1) (R0, R1, R2)) func(context.Context, P0, P1) (Future[R0], Future[R1], Future[R2]) {
+	return func(ctx context.Context, p0 P0, p1 P1) (Future[R0], Future[R1], Future[R2]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2 = fn(ctx, p0, p1)
+			}, &r0, &r1, &r2)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}
+	}
+}
+
+func Async2Ctx_2_4[P0, P1, R0 any, R1 any, R2 any, R3 any](fn func(context.Context, P0, P1) (R0, R1, R2, R3)) func(context.Context, P0, P1) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+	return func(ctx context.Context, p0 P0, p1 P1) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		var r3 R3
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2, &r3)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2, r3 = fn(ctx, p0, p1)
+			}, &r0, &r1, &r2, &r3)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}, func() R3 {
+				wg.Wait()
+				return r3
+			}
+	}
+}
+
+func Async2Ctx_3_0[P0, P1, P2 any](fn func(context.Context, P0, P1, P2)) func(context.Context, P0, P1, P2) Future[any] {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2) Future[any] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer handlePanic()
+			runCtx(ctx, func(ctx context.Context) {
+				fn(ctx, p0, p1, p2)
+			})
+		}()
+		return func() any {
+			wg.Wait()
+			return nil
+		}
+	}
+}
+
+func Async2Ctx_3_1[P0, P1, P2, R0 any](fn func(context.Context, P0, P1, P2) R0) func(context.Context, P0, P1, P2) Future[R0] {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2) Future[R0] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0)
+			runCtx(ctx, func(ctx context.Context) {
+				r0 = fn(ctx, p0, p1, p2)
+			}, &r0)
+		}()
+		return func() R0 {
+			wg.Wait()
+			return r0
+		}
+	}
+}
+
+func Async2Ctx_3_2[P0, P1, P2, R0 any, R1 any](fn func(context.Context, P0, P1, P2) (R0, R1)) func(context.Context, P0, P1, P2) (Future[R0], Future[R1]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2) (Future[R0], Future[R1]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1 = fn(ctx, p0, p1, p2)
+			}, &r0, &r1)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}
+	}
+}
+
+func Async2Ctx_3_3[P0, P1, P2, R0 any, R1 any, R2 any](fn func(context.Context, P0, P1, P2) (R0, R1, R2)) func(context.Context, P0, P1, P2) (Future[R0], Future[R1], Future[R2]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2) (Future[R0], Future[R1], Future[R2]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2 = fn(ctx, p0, p1, p2)
+			}, &r0, &r1, &r2)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}
+	}
+}
+
+func Async2Ctx_3_4[P0, P1, P2, R0 any, R1 any, R2 any, R3 any](fn func(context.Context, P0, P1, P2) (R0, R1, R2, R3)) func(context.Context, P0, P1, P2) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		var r3 R3
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2, &r3)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2, r3 = fn(ctx, p0, p1, p2)
+			}, &r0, &r1, &r2, &r3)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}, func() R3 {
+				wg.Wait()
+				return r3
+			}
+	}
+}
+
+func Async2Ctx_4_0[P0, P1, P2, P3 any](fn func(context.Context, P0, P1, P2, P3)) func(context.Context, P0, P1, P2, P3) Future[any] {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3) Future[any] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer handlePanic()
+			runCtx(ctx, func(ctx context.Context) {
+				fn(ctx, p0, p1, p2, p3)
+			})
+		}()
+		return func() any {
+			wg.Wait()
+			return nil
+		}
+	}
+}
+
+func Async2Ctx_4_1[P0, P1, P2, P3, R0 any](fn func(context.Context, P0, P1, P2, P3) R0) func(context.Context, P0, P1, P2, P3) Future[R0] {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3) Future[R0] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0)
+			runCtx(ctx, func(ctx context.Context) {
+				r0 = fn(ctx, p0, p1, p2, p3)
+			}, &r0)
+		}()
+		return func() R0 {
+			wg.Wait()
+			return r0
+		}
+	}
+}
+
+func Async2Ctx_4_2[P0, P1, P2, P3, R0 any, R1 any](fn func(context.Context, P0, P1, P2, P3) (R0, R1)) func(context.Context, P0, P1, P2, P3) (Future[R0], Future[R1]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3) (Future[R0], Future[R1]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1 = fn(ctx, p0, p1, p2, p3)
+			}, &r0, &r1)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}
+	}
+}
+
+func Async2Ctx_4_3[P0, P1, P2, P3, R0 any, R1 any, R2 any](fn func(context.Context, P0, P1, P2, P3) (R0, R1, R2)) func(context.Context, P0, P1, P2, P3) (Future[R0], Future[R1], Future[R2]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3) (Future[R0], Future[R1], Future[R2]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2 = fn(ctx, p0, p1, p2, p3)
+			}, &r0, &r1, &r2)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}
+	}
+}
+
+func Async2Ctx_4_4[P0, P1, P2, P3, R0 any, R1 any, R2 any, R3 any](fn func(context.Context, P0, P1, P2, P3) (R0, R1, R2, R3)) func(context.Context, P0, P1, P2, P3) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		var r3 R3
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2, &r3)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2, r3 = fn(ctx, p0, p1, p2, p3)
+			}, &r0, &r1, &r2, &r3)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}, func() R3 {
+				wg.Wait()
+				return r3
+			}
+	}
+}
+
+func Async2Ctx_5_0[P0, P1, P2, P3, P4 any](fn func(context.Context, P0, P1, P2, P3, P4)) func(context.Context, P0, P1, P2, P3, P4) Future[any] {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4) Future[any] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer handlePanic()
+			runCtx(ctx, func(ctx context.Context) {
+				fn(ctx, p0, p1, p2, p3, p4)
+			})
+		}()
+		return func() any {
+			wg.Wait()
+			return nil
+		}
+	}
+}
+
+func Async2Ctx_5_1[P0, P1, P2, P3, P4, R0 any](fn func(context.Context, P0, P1, P2, P3, P4) R0) func(context.Context, P0, P1, P2, P3, P4) Future[R0] {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4) Future[R0] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0)
+			runCtx(ctx, func(ctx context.Context) {
+				r0 = fn(ctx, p0, p1, p2, p3, p4)
+			}, &r0)
+		}()
+		return func() R0 {
+			wg.Wait()
+			return r0
+		}
+	}
+}
+
+func Async2Ctx_5_2[P0, P1, P2, P3, P4, R0 any, R1 any](fn func(context.Context, P0, P1, P2, P3, P4) (R0, R1)) func(context.Context, P0, P1, P2, P3, P4) (Future[R0], Future[R1]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4) (Future[R0], Future[R1]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1 = fn(ctx, p0, p1, p2, p3, p4)
+			}, &r0, &r1)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}
+	}
+}
+
+func Async2Ctx_5_3[P0, P1, P2, P3, P4, R0 any, R1 any, R2 any](fn func(context.Context, P0, P1, P2, P3, P4) (R0, R1, R2)) func(context.Context, P0, P1, P2, P3, P4) (Future[R0], Future[R1], Future[R2]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4) (Future[R0], Future[R1], Future[R2]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2 = fn(ctx, p0, p1, p2, p3, p4)
+			}, &r0, &r1, &r2)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}
+	}
+}
+
+func Async2Ctx_5_4[P0, P1, P2, P3, P4, R0 any, R1 any, R2 any, R3 any](fn func(context.Context, P0, P1, P2, P3, P4) (R0, R1, R2, R3)) func(context.Context, P0, P1, P2, P3, P4) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		var r3 R3
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2, &r3)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2, r3 = fn(ctx, p0, p1, p2, p3, p4)
+			}, &r0, &r1, &r2, &r3)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}, func() R3 {
+				wg.Wait()
+				return r3
+			}
+	}
+}
+
+func Async2Ctx_6_0[P0, P1, P2, P3, P4, P5 any](fn func(context.Context, P0, P1, P2, P3, P4, P5)) func(context.Context, P0, P1, P2, P3, P4, P5) Future[any] {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5) Future[any] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer handlePanic()
+			runCtx(ctx, func(ctx context.Context) {
+				fn(ctx, p0, p1, p2, p3, p4, p5)
+			})
+		}()
+		return func() any {
+			wg.Wait()
+			return nil
+		}
+	}
+}
+
+func Async2Ctx_6_1[P0, P1, P2, P3, P4, P5, R0 any](fn func(context.Context, P0, P1, P2, P3, P4, P5) R0) func(context.Context, P0, P1, P2, P3, P4, P5) Future[R0] {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5) Future[R0] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0)
+			runCtx(ctx, func(ctx context.Context) {
+				r0 = fn(ctx, p0, p1, p2, p3, p4, p5)
+			}, &r0)
+		}()
+		return func() R0 {
+			wg.Wait()
+			return r0
+		}
+	}
+}
+
+func Async2Ctx_6_2[P0, P1, P2, P3, P4, P5, R0 any, R1 any](fn func(context.Context, P0, P1, P2, P3, P4, P5) (R0, R1)) func(context.Context, P0, P1, P2, P3, P4, P5) (Future[R0], Future[R1]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5) (Future[R0], Future[R1]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1 = fn(ctx, p0, p1, p2, p3, p4, p5)
+			}, &r0, &r1)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}
+	}
+}
+
+func Async2Ctx_6_3[P0, P1, P2, P3, P4, P5, R0 any, R1 any, R2 any](fn func(context.Context, P0, P1, P2, P3, P4, P5) (R0, R1, R2)) func(context.Context, P0, P1, P2, P3, P4, P5) (Future[R0], Future[R1], Future[R2]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5) (Future[R0], Future[R1], Future[R2]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2 = fn(ctx, p0, p1, p2, p3, p4, p5)
+			}, &r0, &r1, &r2)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}
+	}
+}
+
+func Async2Ctx_6_4[P0, P1, P2, P3, P4, P5, R0 any, R1 any, R2 any, R3 any](fn func(context.Context, P0, P1, P2, P3, P4, P5) (R0, R1, R2, R3)) func(context.Context, P0, P1, P2, P3, P4, P5) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		var r3 R3
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2, &r3)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2, r3 = fn(ctx, p0, p1, p2, p3, p4, p5)
+			}, &r0, &r1, &r2, &r3)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}, func() R3 {
+				wg.Wait()
+				return r3
+			}
+	}
+}
+
+func Async2Ctx_7_0[P0, P1, P2, P3, P4, P5, P6 any](fn func(context.Context, P0, P1, P2, P3, P4, P5, P6)) func(context.Context, P0, P1, P2, P3, P4, P5, P6) Future[any] {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5, p6 P6) Future[any] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer handlePanic()
+			runCtx(ctx, func(ctx context.Context) {
+				fn(ctx, p0, p1, p2, p3, p4, p5, p6)
+			})
+		}()
+		return func() any {
+			wg.Wait()
+			return nil
+		}
+	}
+}
+
+func Async2Ctx_7_1[P0, P1, P2, P3, P4, P5, P6, R0 any](fn func(context.Context, P0, P1, P2, P3, P4, P5, P6) R0) func(context.Context, P0, P1, P2, P3, P4, P5, P6) Future[R0] {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5, p6 P6) Future[R0] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0)
+			runCtx(ctx, func(ctx context.Context) {
+				r0 = fn(ctx, p0, p1, p2, p3, p4, p5, p6)
+			}, &r0)
+		}()
+		return func() R0 {
+			wg.Wait()
+			return r0
+		}
+	}
+}
+
+func Async2Ctx_7_2[P0, P1, P2, P3, P4, P5, P6, R0 any, R1 any](fn func(context.Context, P0, P1, P2, P3, P4, P5, P6) (R0, R1)) func(context.Context, P0, P1, P2, P3, P4, P5, P6) (Future[R0], Future[R1]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5, p6 P6) (Future[R0], Future[R1]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1 = fn(ctx, p0, p1, p2, p3, p4, p5, p6)
+			}, &r0, &r1)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}
+	}
+}
+
+func Async2Ctx_7_3[P0, P1, P2, P3, P4, P5, P6, R0 any, R1 any, R2 any](fn func(context.Context, P0, P1, P2, P3, P4, P5, P6) (R0, R1, R2)) func(context.Context, P0, P1, P2, P3, P4, P5, P6) (Future[R0], Future[R1], Future[R2]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5, p6 P6) (Future[R0], Future[R1], Future[R2]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2 = fn(ctx, p0, p1, p2, p3, p4, p5, p6)
+			}, &r0, &r1, &r2)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}
+	}
+}
+
+func Async2Ctx_7_4[P0, P1, P2, P3, P4, P5, P6, R0 any, R1 any, R2 any, R3 any](fn func(context.Context, P0, P1, P2, P3, P4, P5, P6) (R0, R1, R2, R3)) func(context.Context, P0, P1, P2, P3, P4, P5, P6) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5, p6 P6) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		var r3 R3
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2, &r3)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2, r3 = fn(ctx, p0, p1, p2, p3, p4, p5, p6)
+			}, &r0, &r1, &r2, &r3)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}, func() R3 {
+				wg.Wait()
+				return r3
+			}
+	}
+}
+
+func Async2Ctx_8_0[P0, P1, P2, P3, P4, P5, P6, P7 any](fn func(context.Context, P0, P1, P2, P3, P4, P5, P6, P7)) func(context.Context, P0, P1, P2, P3, P4, P5, P6, P7) Future[any] {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5, p6 P6, p7 P7) Future[any] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer handlePanic()
+			runCtx(ctx, func(ctx context.Context) {
+				fn(ctx, p0, p1, p2, p3, p4, p5, p6, p7)
+			})
+		}()
+		return func() any {
+			wg.Wait()
+			return nil
+		}
+	}
+}
+
+func Async2Ctx_8_1[P0, P1, P2, P3, P4, P5, P6, P7, R0 any](fn func(context.Context, P0, P1, P2, P3, P4, P5, P6, P7) R0) func(context.Context, P0, P1, P2, P3, P4, P5, P6, P7) Future[R0] {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5, p6 P6, p7 P7) Future[R0] {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0)
+			runCtx(ctx, func(ctx context.Context) {
+				r0 = fn(ctx, p0, p1, p2, p3, p4, p5, p6, p7)
+			}, &r0)
+		}()
+		return func() R0 {
+			wg.Wait()
+			return r0
+		}
+	}
+}
+
+func Async2Ctx_8_2[P0, P1, P2, P3, P4, P5, P6, P7, R0 any, R1 any](fn func(context.Context, P0, P1, P2, P3, P4, P5, P6, P7) (R0, R1)) func(context.Context, P0, P1, P2, P3, P4, P5, P6, P7) (Future[R0], Future[R1]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5, p6 P6, p7 P7) (Future[R0], Future[R1]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1 = fn(ctx, p0, p1, p2, p3, p4, p5, p6, p7)
+			}, &r0, &r1)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}
+	}
+}
+
+func Async2Ctx_8_3[P0, P1, P2, P3, P4, P5, P6, P7, R0 any, R1 any, R2 any](fn func(context.Context, P0, P1, P2, P3, P4, P5, P6, P7) (R0, R1, R2)) func(context.Context, P0, P1, P2, P3, P4, P5, P6, P7) (Future[R0], Future[R1], Future[R2]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5, p6 P6, p7 P7) (Future[R0], Future[R1], Future[R2]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2 = fn(ctx, p0, p1, p2, p3, p4, p5, p6, p7)
+			}, &r0, &r1, &r2)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}
+	}
+}
+
+func Async2Ctx_8_4[P0, P1, P2, P3, P4, P5, P6, P7, R0 any, R1 any, R2 any, R3 any](fn func(context.Context, P0, P1, P2, P3, P4, P5, P6, P7) (R0, R1, R2, R3)) func(context.Context, P0, P1, P2, P3, P4, P5, P6, P7) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+	return func(ctx context.Context, p0 P0, p1 P1, p2 P2, p3 P3, p4 P4, p5 P5, p6 P6, p7 P7) (Future[R0], Future[R1], Future[R2], Future[R3]) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var r0 R0
+		var r1 R1
+		var r2 R2
+		var r3 R3
+		go func() {
+			defer wg.Done()
+			defer handlePanic(&r0, &r1, &r2, &r3)
+			runCtx(ctx, func(ctx context.Context) {
+				r0, r1, r2, r3 = fn(ctx, p0, p1, p2, p3, p4, p5, p6, p7)
+			}, &r0, &r1, &r2, &r3)
+		}()
+		return func() R0 {
+				wg.Wait()
+				return r0
+			}, func() R1 {
+				wg.Wait()
+				return r1
+			}, func() R2 {
+				wg.Wait()
+				return r2
+			}, func() R3 {
+				wg.Wait()
+				return r3
+			}
+	}
+}
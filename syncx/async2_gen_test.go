@@ -0,0 +1,1186 @@
+// Code generated by gen_async.go; DO NOT EDIT.
+
+package syncx
+
+import (
+	"context"
+	"testing"
+)
+
+// 测试：Async2 系列的参数和返回值一一对应
+func TestAsync2Matrix(t *testing.T) {
+	t.Run("0_0", func(t *testing.T) {
+		got := -1
+		async := Async2_0_0(func() {
+			got = 0
+		})
+		f := async()
+		f()
+		if got != 0 {
+			t.Fatalf("want 0, got %d", got)
+		}
+	})
+	t.Run("0_1", func(t *testing.T) {
+		async := Async2_0_1(func() int {
+			return 0 + 0
+		})
+		f0 := async()
+		if v := f0(); v != 0 {
+			t.Fatalf("want 0, got %d", v)
+		}
+	})
+	t.Run("0_2", func(t *testing.T) {
+		async := Async2_0_2(func() (int, int) {
+			return 0 + 0, 0 + 1
+		})
+		f0, f1 := async()
+		if v := f0(); v != 0 {
+			t.Fatalf("want 0, got %d", v)
+		}
+		if v := f1(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+	})
+	t.Run("0_3", func(t *testing.T) {
+		async := Async2_0_3(func() (int, int, int) {
+			return 0 + 0, 0 + 1, 0 + 2
+		})
+		f0, f1, f2 := async()
+		if v := f0(); v != 0 {
+			t.Fatalf("want 0, got %d", v)
+		}
+		if v := f1(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+		if v := f2(); v != 2 {
+			t.Fatalf("want 2, got %d", v)
+		}
+	})
+	t.Run("0_4", func(t *testing.T) {
+		async := Async2_0_4(func() (int, int, int, int) {
+			return 0 + 0, 0 + 1, 0 + 2, 0 + 3
+		})
+		f0, f1, f2, f3 := async()
+		if v := f0(); v != 0 {
+			t.Fatalf("want 0, got %d", v)
+		}
+		if v := f1(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+		if v := f2(); v != 2 {
+			t.Fatalf("want 2, got %d", v)
+		}
+		if v := f3(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+	})
+	t.Run("1_0", func(t *testing.T) {
+		got := -1
+		async := Async2_1_0(func(p0 int) {
+			got = p0
+		})
+		f := async(1)
+		f()
+		if got != 1 {
+			t.Fatalf("want 1, got %d", got)
+		}
+	})
+	t.Run("1_1", func(t *testing.T) {
+		async := Async2_1_1(func(p0 int) int {
+			return p0 + 0
+		})
+		f0 := async(1)
+		if v := f0(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+	})
+	t.Run("1_2", func(t *testing.T) {
+		async := Async2_1_2(func(p0 int) (int, int) {
+			return p0 + 0, p0 + 1
+		})
+		f0, f1 := async(1)
+		if v := f0(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+		if v := f1(); v != 2 {
+			t.Fatalf("want 2, got %d", v)
+		}
+	})
+	t.Run("1_3", func(t *testing.T) {
+		async := Async2_1_3(func(p0 int) (int, int, int) {
+			return p0 + 0, p0 + 1, p0 + 2
+		})
+		f0, f1, f2 := async(1)
+		if v := f0(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+		if v := f1(); v != 2 {
+			t.Fatalf("want 2, got %d", v)
+		}
+		if v := f2(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+	})
+	t.Run("1_4", func(t *testing.T) {
+		async := Async2_1_4(func(p0 int) (int, int, int, int) {
+			return p0 + 0, p0 + 1, p0 + 2, p0 + 3
+		})
+		f0, f1, f2, f3 := async(1)
+		if v := f0(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+		if v := f1(); v != 2 {
+			t.Fatalf("want 2, got %d", v)
+		}
+		if v := f2(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+		if v := f3(); v != 4 {
+			t.Fatalf("want 4, got %d", v)
+		}
+	})
+	t.Run("2_0", func(t *testing.T) {
+		got := -1
+		async := Async2_2_0(func(p0 int, p1 int) {
+			got = p0 + p1
+		})
+		f := async(1, 2)
+		f()
+		if got != 3 {
+			t.Fatalf("want 3, got %d", got)
+		}
+	})
+	t.Run("2_1", func(t *testing.T) {
+		async := Async2_2_1(func(p0 int, p1 int) int {
+			return p0 + p1 + 0
+		})
+		f0 := async(1, 2)
+		if v := f0(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+	})
+	t.Run("2_2", func(t *testing.T) {
+		async := Async2_2_2(func(p0 int, p1 int) (int, int) {
+			return p0 + p1 + 0, p0 + p1 + 1
+		})
+		f0, f1 := async(1, 2)
+		if v := f0(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+		if v := f1(); v != 4 {
+			t.Fatalf("want 4, got %d", v)
+		}
+	})
+	t.Run("2_3", func(t *testing.T) {
+		async := Async2_2_3(func(p0 int, p1 int) (int, int, int) {
+			return p0 + p1 + 0, p0 + p1 + 1, p0 + p1 + 2
+		})
+		f0, f1, f2 := async(1, 2)
+		if v := f0(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+		if v := f1(); v != 4 {
+			t.Fatalf("want 4, got %d", v)
+		}
+		if v := f2(); v != 5 {
+			t.Fatalf("want 5, got %d", v)
+		}
+	})
+	t.Run("2_4", func(t *testing.T) {
+		async := Async2_2_4(func(p0 int, p1 int) (int, int, int, int) {
+			return p0 + p1 + 0, p0 + p1 + 1, p0 + p1 + 2, p0 + p1 + 3
+		})
+		f0, f1, f2, f3 := async(1, 2)
+		if v := f0(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+		if v := f1(); v != 4 {
+			t.Fatalf("want 4, got %d", v)
+		}
+		if v := f2(); v != 5 {
+			t.Fatalf("want 5, got %d", v)
+		}
+		if v := f3(); v != 6 {
+			t.Fatalf("want 6, got %d", v)
+		}
+	})
+	t.Run("3_0", func(t *testing.T) {
+		got := -1
+		async := Async2_3_0(func(p0 int, p1 int, p2 int) {
+			got = p0 + p1 + p2
+		})
+		f := async(1, 2, 3)
+		f()
+		if got != 6 {
+			t.Fatalf("want 6, got %d", got)
+		}
+	})
+	t.Run("3_1", func(t *testing.T) {
+		async := Async2_3_1(func(p0 int, p1 int, p2 int) int {
+			return p0 + p1 + p2 + 0
+		})
+		f0 := async(1, 2, 3)
+		if v := f0(); v != 6 {
+			t.Fatalf("want 6, got %d", v)
+		}
+	})
+	t.Run("3_2", func(t *testing.T) {
+		async := Async2_3_2(func(p0 int, p1 int, p2 int) (int, int) {
+			return p0 + p1 + p2 + 0, p0 + p1 + p2 + 1
+		})
+		f0, f1 := async(1, 2, 3)
+		if v := f0(); v != 6 {
+			t.Fatalf("want 6, got %d", v)
+		}
+		if v := f1(); v != 7 {
+			t.Fatalf("want 7, got %d", v)
+		}
+	})
+	t.Run("3_3", func(t *testing.T) {
+		async := Async2_3_3(func(p0 int, p1 int, p2 int) (int, int, int) {
+			return p0 + p1 + p2 + 0, p0 + p1 + p2 + 1, p0 + p1 + p2 + 2
+		})
+		f0, f1, f2 := async(1, 2, 3)
+		if v := f0(); v != 6 {
+			t.Fatalf("want 6, got %d", v)
+		}
+		if v := f1(); v != 7 {
+			t.Fatalf("want 7, got %d", v)
+		}
+		if v := f2(); v != 8 {
+			t.Fatalf("want 8, got %d", v)
+		}
+	})
+	t.Run("3_4", func(t *testing.T) {
+		async := Async2_3_4(func(p0 int, p1 int, p2 int) (int, int, int, int) {
+			return p0 + p1 + p2 + 0, p0 + p1 + p2 + 1, p0 + p1 + p2 + 2, p0 + p1 + p2 + 3
+		})
+		f0, f1, f2, f3 := async(1, 2, 3)
+		if v := f0(); v != 6 {
+			t.Fatalf("want 6, got %d", v)
+		}
+		if v := f1(); v != 7 {
+			t.Fatalf("want 7, got %d", v)
+		}
+		if v := f2(); v != 8 {
+			t.Fatalf("want 8, got %d", v)
+		}
+		if v := f3(); v != 9 {
+			t.Fatalf("want 9, got %d", v)
+		}
+	})
+	t.Run("4_0", func(t *testing.T) {
+		got := -1
+		async := Async2_4_0(func(p0 int, p1 int, p2 int, p3 int) {
+			got = p0 + p1 + p2 + p3
+		})
+		f := async(1, 2, 3, 4)
+		f()
+		if got != 10 {
+			t.Fatalf("want 10, got %d", got)
+		}
+	})
+	t.Run("4_1", func(t *testing.T) {
+		async := Async2_4_1(func(p0 int, p1 int, p2 int, p3 int) int {
+			return p0 + p1 + p2 + p3 + 0
+		})
+		f0 := async(1, 2, 3, 4)
+		if v := f0(); v != 10 {
+			t.Fatalf("want 10, got %d", v)
+		}
+	})
+	t.Run("4_2", func(t *testing.T) {
+		async := Async2_4_2(func(p0 int, p1 int, p2 int, p3 int) (int, int) {
+			return p0 + p1 + p2 + p3 + 0, p0 + p1 + p2 + p3 + 1
+		})
+		f0, f1 := async(1, 2, 3, 4)
+		if v := f0(); v != 10 {
+			t.Fatalf("want 10, got %d", v)
+		}
+		if v := f1(); v != 11 {
+			t.Fatalf("want 11, got %d", v)
+		}
+	})
+	t.Run("4_3", func(t *testing.T) {
+		async := Async2_4_3(func(p0 int, p1 int, p2 int, p3 int) (int, int, int) {
+			return p0 + p1 + p2 + p3 + 0, p0 + p1 + p2 + p3 + 1, p0 + p1 + p2 + p3 + 2
+		})
+		f0, f1, f2 := async(1, 2, 3, 4)
+		if v := f0(); v != 10 {
+			t.Fatalf("want 10, got %d", v)
+		}
+		if v := f1(); v != 11 {
+			t.Fatalf("want 11, got %d", v)
+		}
+		if v := f2(); v != 12 {
+			t.Fatalf("want 12, got %d", v)
+		}
+	})
+	t.Run("4_4", func(t *testing.T) {
+		async := Async2_4_4(func(p0 int, p1 int, p2 int, p3 int) (int, int, int, int) {
+			return p0 + p1 + p2 + p3 + 0, p0 + p1 + p2 + p3 + 1, p0 + p1 + p2 + p3 + 2, p0 + p1 + p2 + p3 + 3
+		})
+		f0, f1, f2, f3 := async(1, 2, 3, 4)
+		if v := f0(); v != 10 {
+			t.Fatalf("want 10, got %d", v)
+		}
+		if v := f1(); v != 11 {
+			t.Fatalf("want 11, got %d", v)
+		}
+		if v := f2(); v != 12 {
+			t.Fatalf("want 12, got %d", v)
+		}
+		if v := f3(); v != 13 {
+			t.Fatalf("want 13, got %d", v)
+		}
+	})
+	t.Run("5_0", func(t *testing.T) {
+		got := -1
+		async := Async2_5_0(func(p0 int, p1 int, p2 int, p3 int, p4 int) {
+			got = p0 + p1 + p2 + p3 + p4
+		})
+		f := async(1, 2, 3, 4, 5)
+		f()
+		if got != 15 {
+			t.Fatalf("want 15, got %d", got)
+		}
+	})
+	t.Run("5_1", func(t *testing.T) {
+		async := Async2_5_1(func(p0 int, p1 int, p2 int, p3 int, p4 int) int {
+			return p0 + p1 + p2 + p3 + p4 + 0
+		})
+		f0 := async(1, 2, 3, 4, 5)
+		if v := f0(); v != 15 {
+			t.Fatalf("want 15, got %d", v)
+		}
+	})
+	t.Run("5_2", func(t *testing.T) {
+		async := Async2_5_2(func(p0 int, p1 int, p2 int, p3 int, p4 int) (int, int) {
+			return p0 + p1 + p2 + p3 + p4 + 0, p0 + p1 + p2 + p3 + p4 + 1
+		})
+		f0, f1 := async(1, 2, 3, 4, 5)
+		if v := f0(); v != 15 {
+			t.Fatalf("want 15, got %d", v)
+		}
+		if v := f1(); v != 16 {
+			t.Fatalf("want 16, got %d", v)
+		}
+	})
+	t.Run("5_3", func(t *testing.T) {
+		async := Async2_5_3(func(p0 int, p1 int, p2 int, p3 int, p4 int) (int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + 0, p0 + p1 + p2 + p3 + p4 + 1, p0 + p1 + p2 + p3 + p4 + 2
+		})
+		f0, f1, f2 := async(1, 2, 3, 4, 5)
+		if v := f0(); v != 15 {
+			t.Fatalf("want 15, got %d", v)
+		}
+		if v := f1(); v != 16 {
+			t.Fatalf("want 16, got %d", v)
+		}
+		if v := f2(); v != 17 {
+			t.Fatalf("want 17, got %d", v)
+		}
+	})
+	t.Run("5_4", func(t *testing.T) {
+		async := Async2_5_4(func(p0 int, p1 int, p2 int, p3 int, p4 int) (int, int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + 0, p0 + p1 + p2 + p3 + p4 + 1, p0 + p1 + p2 + p3 + p4 + 2, p0 + p1 + p2 + p3 + p4 + 3
+		})
+		f0, f1, f2, f3 := async(1, 2, 3, 4, 5)
+		if v := f0(); v != 15 {
+			t.Fatalf("want 15, got %d", v)
+		}
+		if v := f1(); v != 16 {
+			t.Fatalf("want 16, got %d", v)
+		}
+		if v := f2(); v != 17 {
+			t.Fatalf("want 17, got %d", v)
+		}
+		if v := f3(); v != 18 {
+			t.Fatalf("want 18, got %d", v)
+		}
+	})
+	t.Run("6_0", func(t *testing.T) {
+		got := -1
+		async := Async2_6_0(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int) {
+			got = p0 + p1 + p2 + p3 + p4 + p5
+		})
+		f := async(1, 2, 3, 4, 5, 6)
+		f()
+		if got != 21 {
+			t.Fatalf("want 21, got %d", got)
+		}
+	})
+	t.Run("6_1", func(t *testing.T) {
+		async := Async2_6_1(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int) int {
+			return p0 + p1 + p2 + p3 + p4 + p5 + 0
+		})
+		f0 := async(1, 2, 3, 4, 5, 6)
+		if v := f0(); v != 21 {
+			t.Fatalf("want 21, got %d", v)
+		}
+	})
+	t.Run("6_2", func(t *testing.T) {
+		async := Async2_6_2(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int) (int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + 0, p0 + p1 + p2 + p3 + p4 + p5 + 1
+		})
+		f0, f1 := async(1, 2, 3, 4, 5, 6)
+		if v := f0(); v != 21 {
+			t.Fatalf("want 21, got %d", v)
+		}
+		if v := f1(); v != 22 {
+			t.Fatalf("want 22, got %d", v)
+		}
+	})
+	t.Run("6_3", func(t *testing.T) {
+		async := Async2_6_3(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int) (int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + 0, p0 + p1 + p2 + p3 + p4 + p5 + 1, p0 + p1 + p2 + p3 + p4 + p5 + 2
+		})
+		f0, f1, f2 := async(1, 2, 3, 4, 5, 6)
+		if v := f0(); v != 21 {
+			t.Fatalf("want 21, got %d", v)
+		}
+		if v := f1(); v != 22 {
+			t.Fatalf("want 22, got %d", v)
+		}
+		if v := f2(); v != 23 {
+			t.Fatalf("want 23, got %d", v)
+		}
+	})
+	t.Run("6_4", func(t *testing.T) {
+		async := Async2_6_4(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int) (int, int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + 0, p0 + p1 + p2 + p3 + p4 + p5 + 1, p0 + p1 + p2 + p3 + p4 + p5 + 2, p0 + p1 + p2 + p3 + p4 + p5 + 3
+		})
+		f0, f1, f2, f3 := async(1, 2, 3, 4, 5, 6)
+		if v := f0(); v != 21 {
+			t.Fatalf("want 21, got %d", v)
+		}
+		if v := f1(); v != 22 {
+			t.Fatalf("want 22, got %d", v)
+		}
+		if v := f2(); v != 23 {
+			t.Fatalf("want 23, got %d", v)
+		}
+		if v := f3(); v != 24 {
+			t.Fatalf("want 24, got %d", v)
+		}
+	})
+	t.Run("7_0", func(t *testing.T) {
+		got := -1
+		async := Async2_7_0(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int) {
+			got = p0 + p1 + p2 + p3 + p4 + p5 + p6
+		})
+		f := async(1, 2, 3, 4, 5, 6, 7)
+		f()
+		if got != 28 {
+			t.Fatalf("want 28, got %d", got)
+		}
+	})
+	t.Run("7_1", func(t *testing.T) {
+		async := Async2_7_1(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int) int {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + 0
+		})
+		f0 := async(1, 2, 3, 4, 5, 6, 7)
+		if v := f0(); v != 28 {
+			t.Fatalf("want 28, got %d", v)
+		}
+	})
+	t.Run("7_2", func(t *testing.T) {
+		async := Async2_7_2(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int) (int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + 0, p0 + p1 + p2 + p3 + p4 + p5 + p6 + 1
+		})
+		f0, f1 := async(1, 2, 3, 4, 5, 6, 7)
+		if v := f0(); v != 28 {
+			t.Fatalf("want 28, got %d", v)
+		}
+		if v := f1(); v != 29 {
+			t.Fatalf("want 29, got %d", v)
+		}
+	})
+	t.Run("7_3", func(t *testing.T) {
+		async := Async2_7_3(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int) (int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + 0, p0 + p1 + p2 + p3 + p4 + p5 + p6 + 1, p0 + p1 + p2 + p3 + p4 + p5 + p6 + 2
+		})
+		f0, f1, f2 := async(1, 2, 3, 4, 5, 6, 7)
+		if v := f0(); v != 28 {
+			t.Fatalf("want 28, got %d", v)
+		}
+		if v := f1(); v != 29 {
+			t.Fatalf("want 29, got %d", v)
+		}
+		if v := f2(); v != 30 {
+			t.Fatalf("want 30, got %d", v)
+		}
+	})
+	t.Run("7_4", func(t *testing.T) {
+		async := Async2_7_4(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int) (int, int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + 0, p0 + p1 + p2 + p3 + p4 + p5 + p6 + 1, p0 + p1 + p2 + p3 + p4 + p5 + p6 + 2, p0 + p1 + p2 + p3 + p4 + p5 + p6 + 3
+		})
+		f0, f1, f2, f3 := async(1, 2, 3, 4, 5, 6, 7)
+		if v := f0(); v != 28 {
+			t.Fatalf("want 28, got %d", v)
+		}
+		if v := f1(); v != 29 {
+			t.Fatalf("want 29, got %d", v)
+		}
+		if v := f2(); v != 30 {
+			t.Fatalf("want 30, got %d", v)
+		}
+		if v := f3(); v != 31 {
+			t.Fatalf("want 31, got %d", v)
+		}
+	})
+	t.Run("8_0", func(t *testing.T) {
+		got := -1
+		async := Async2_8_0(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int, p7 int) {
+			got = p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7
+		})
+		f := async(1, 2, 3, 4, 5, 6, 7, 8)
+		f()
+		if got != 36 {
+			t.Fatalf("want 36, got %d", got)
+		}
+	})
+	t.Run("8_1", func(t *testing.T) {
+		async := Async2_8_1(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int, p7 int) int {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 0
+		})
+		f0 := async(1, 2, 3, 4, 5, 6, 7, 8)
+		if v := f0(); v != 36 {
+			t.Fatalf("want 36, got %d", v)
+		}
+	})
+	t.Run("8_2", func(t *testing.T) {
+		async := Async2_8_2(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int, p7 int) (int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 0, p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 1
+		})
+		f0, f1 := async(1, 2, 3, 4, 5, 6, 7, 8)
+		if v := f0(); v != 36 {
+			t.Fatalf("want 36, got %d", v)
+		}
+		if v := f1(); v != 37 {
+			t.Fatalf("want 37, got %d", v)
+		}
+	})
+	t.Run("8_3", func(t *testing.T) {
+		async := Async2_8_3(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int, p7 int) (int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 0, p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 1, p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 2
+		})
+		f0, f1, f2 := async(1, 2, 3, 4, 5, 6, 7, 8)
+		if v := f0(); v != 36 {
+			t.Fatalf("want 36, got %d", v)
+		}
+		if v := f1(); v != 37 {
+			t.Fatalf("want 37, got %d", v)
+		}
+		if v := f2(); v != 38 {
+			t.Fatalf("want 38, got %d", v)
+		}
+	})
+	t.Run("8_4", func(t *testing.T) {
+		async := Async2_8_4(func(p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int, p7 int) (int, int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 0, p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 1, p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 2, p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 3
+		})
+		f0, f1, f2, f3 := async(1, 2, 3, 4, 5, 6, 7, 8)
+		if v := f0(); v != 36 {
+			t.Fatalf("want 36, got %d", v)
+		}
+		if v := f1(); v != 37 {
+			t.Fatalf("want 37, got %d", v)
+		}
+		if v := f2(); v != 38 {
+			t.Fatalf("want 38, got %d", v)
+		}
+		if v := f3(); v != 39 {
+			t.Fatalf("want 39, got %d", v)
+		}
+	})
+}
+
+// 测试：Async2Ctx 系列的参数和返回值一一对应
+func TestAsync2CtxMatrix(t *testing.T) {
+	t.Run("0_0", func(t *testing.T) {
+		got := -1
+		async := Async2Ctx_0_0(func(ctx context.Context) {
+			got = 0
+		})
+		f := async(context.Background())
+		f()
+		if got != 0 {
+			t.Fatalf("want 0, got %d", got)
+		}
+	})
+	t.Run("0_1", func(t *testing.T) {
+		async := Async2Ctx_0_1(func(ctx context.Context) int {
+			return 0 + 0
+		})
+		f0 := async(context.Background())
+		if v := f0(); v != 0 {
+			t.Fatalf("want 0, got %d", v)
+		}
+	})
+	t.Run("0_2", func(t *testing.T) {
+		async := Async2Ctx_0_2(func(ctx context.Context) (int, int) {
+			return 0 + 0, 0 + 1
+		})
+		f0, f1 := async(context.Background())
+		if v := f0(); v != 0 {
+			t.Fatalf("want 0, got %d", v)
+		}
+		if v := f1(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+	})
+	t.Run("0_3", func(t *testing.T) {
+		async := Async2Ctx_0_3(func(ctx context.Context) (int, int, int) {
+			return 0 + 0, 0 + 1, 0 + 2
+		})
+		f0, f1, f2 := async(context.Background())
+		if v := f0(); v != 0 {
+			t.Fatalf("want 0, got %d", v)
+		}
+		if v := f1(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+		if v := f2(); v != 2 {
+			t.Fatalf("want 2, got %d", v)
+		}
+	})
+	t.Run("0_4", func(t *testing.T) {
+		async := Async2Ctx_0_4(func(ctx context.Context) (int, int, int, int) {
+			return 0 + 0, 0 + 1, 0 + 2, 0 + 3
+		})
+		f0, f1, f2, f3 := async(context.Background())
+		if v := f0(); v != 0 {
+			t.Fatalf("want 0, got %d", v)
+		}
+		if v := f1(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+		if v := f2(); v != 2 {
+			t.Fatalf("want 2, got %d", v)
+		}
+		if v := f3(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+	})
+	t.Run("1_0", func(t *testing.T) {
+		got := -1
+		async := Async2Ctx_1_0(func(ctx context.Context, p0 int) {
+			got = p0
+		})
+		f := async(context.Background(), 1)
+		f()
+		if got != 1 {
+			t.Fatalf("want 1, got %d", got)
+		}
+	})
+	t.Run("1_1", func(t *testing.T) {
+		async := Async2Ctx_1_1(func(ctx context.Context, p0 int) int {
+			return p0 + 0
+		})
+		f0 := async(context.Background(), 1)
+		if v := f0(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+	})
+	t.Run("1_2", func(t *testing.T) {
+		async := Async2Ctx_1_2(func(ctx context.Context, p0 int) (int, int) {
+			return p0 + 0, p0 + 1
+		})
+		f0, f1 := async(context.Background(), 1)
+		if v := f0(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+		if v := f1(); v != 2 {
+			t.Fatalf("want 2, got %d", v)
+		}
+	})
+	t.Run("1_3", func(t *testing.T) {
+		async := Async2Ctx_1_3(func(ctx context.Context, p0 int) (int, int, int) {
+			return p0 + 0, p0 + 1, p0 + 2
+		})
+		f0, f1, f2 := async(context.Background(), 1)
+		if v := f0(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+		if v := f1(); v != 2 {
+			t.Fatalf("want 2, got %d", v)
+		}
+		if v := f2(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+	})
+	t.Run("1_4", func(t *testing.T) {
+		async := Async2Ctx_1_4(func(ctx context.Context, p0 int) (int, int, int, int) {
+			return p0 + 0, p0 + 1, p0 + 2, p0 + 3
+		})
+		f0, f1, f2, f3 := async(context.Background(), 1)
+		if v := f0(); v != 1 {
+			t.Fatalf("want 1, got %d", v)
+		}
+		if v := f1(); v != 2 {
+			t.Fatalf("want 2, got %d", v)
+		}
+		if v := f2(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+		if v := f3(); v != 4 {
+			t.Fatalf("want 4, got %d", v)
+		}
+	})
+	t.Run("2_0", func(t *testing.T) {
+		got := -1
+		async := Async2Ctx_2_0(func(ctx context.Context, p0 int, p1 int) {
+			got = p0 + p1
+		})
+		f := async(context.Background(), 1, 2)
+		f()
+		if got != 3 {
+			t.Fatalf("want 3, got %d", got)
+		}
+	})
+	t.Run("2_1", func(t *testing.T) {
+		async := Async2Ctx_2_1(func(ctx context.Context, p0 int, p1 int) int {
+			return p0 + p1 + 0
+		})
+		f0 := async(context.Background(), 1, 2)
+		if v := f0(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+	})
+	t.Run("2_2", func(t *testing.T) {
+		async := Async2Ctx_2_2(func(ctx context.Context, p0 int, p1 int) (int, int) {
+			return p0 + p1 + 0, p0 + p1 + 1
+		})
+		f0, f1 := async(context.Background(), 1, 2)
+		if v := f0(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+		if v := f1(); v != 4 {
+			t.Fatalf("want 4, got %d", v)
+		}
+	})
+	t.Run("2_3", func(t *testing.T) {
+		async := Async2Ctx_2_3(func(ctx context.Context, p0 int, p1 int) (int, int, int) {
+			return p0 + p1 + 0, p0 + p1 + 1, p0 + p1 + 2
+		})
+		f0, f1, f2 := async(context.Background(), 1, 2)
+		if v := f0(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+		if v := f1(); v != 4 {
+			t.Fatalf("want 4, got %d", v)
+		}
+		if v := f2(); v != 5 {
+			t.Fatalf("want 5, got %d", v)
+		}
+	})
+	t.Run("2_4", func(t *testing.T) {
+		async := Async2Ctx_2_4(func(ctx context.Context, p0 int, p1 int) (int, int, int, int) {
+			return p0 + p1 + 0, p0 + p1 + 1, p0 + p1 + 2, p0 + p1 + 3
+		})
+		f0, f1, f2, f3 := async(context.Background(), 1, 2)
+		if v := f0(); v != 3 {
+			t.Fatalf("want 3, got %d", v)
+		}
+		if v := f1(); v != 4 {
+			t.Fatalf("want 4, got %d", v)
+		}
+		if v := f2(); v != 5 {
+			t.Fatalf("want 5, got %d", v)
+		}
+		if v := f3(); v != 6 {
+			t.Fatalf("want 6, got %d", v)
+		}
+	})
+	t.Run("3_0", func(t *testing.T) {
+		got := -1
+		async := Async2Ctx_3_0(func(ctx context.Context, p0 int, p1 int, p2 int) {
+			got = p0 + p1 + p2
+		})
+		f := async(context.Background(), 1, 2, 3)
+		f()
+		if got != 6 {
+			t.Fatalf("want 6, got %d", got)
+		}
+	})
+	t.Run("3_1", func(t *testing.T) {
+		async := Async2Ctx_3_1(func(ctx context.Context, p0 int, p1 int, p2 int) int {
+			return p0 + p1 + p2 + 0
+		})
+		f0 := async(context.Background(), 1, 2, 3)
+		if v := f0(); v != 6 {
+			t.Fatalf("want 6, got %d", v)
+		}
+	})
+	t.Run("3_2", func(t *testing.T) {
+		async := Async2Ctx_3_2(func(ctx context.Context, p0 int, p1 int, p2 int) (int, int) {
+			return p0 + p1 + p2 + 0, p0 + p1 + p2 + 1
+		})
+		f0, f1 := async(context.Background(), 1, 2, 3)
+		if v := f0(); v != 6 {
+			t.Fatalf("want 6, got %d", v)
+		}
+		if v := f1(); v != 7 {
+			t.Fatalf("want 7, got %d", v)
+		}
+	})
+	t.Run("3_3", func(t *testing.T) {
+		async := Async2Ctx_3_3(func(ctx context.Context, p0 int, p1 int, p2 int) (int, int, int) {
+			return p0 + p1 + p2 + 0, p0 + p1 + p2 + 1, p0 + p1 + p2 + 2
+		})
+		f0, f1, f2 := async(context.Background(), 1, 2, 3)
+		if v := f0(); v != 6 {
+			t.Fatalf("want 6, got %d", v)
+		}
+		if v := f1(); v != 7 {
+			t.Fatalf("want 7, got %d", v)
+		}
+		if v := f2(); v != 8 {
+			t.Fatalf("want 8, got %d", v)
+		}
+	})
+	t.Run("3_4", func(t *testing.T) {
+		async := Async2Ctx_3_4(func(ctx context.Context, p0 int, p1 int, p2 int) (int, int, int, int) {
+			return p0 + p1 + p2 + 0, p0 + p1 + p2 + 1, p0 + p1 + p2 + 2, p0 + p1 + p2 + 3
+		})
+		f0, f1, f2, f3 := async(context.Background(), 1, 2, 3)
+		if v := f0(); v != 6 {
+			t.Fatalf("want 6, got %d", v)
+		}
+		if v := f1(); v != 7 {
+			t.Fatalf("want 7, got %d", v)
+		}
+		if v := f2(); v != 8 {
+			t.Fatalf("want 8, got %d", v)
+		}
+		if v := f3(); v != 9 {
+			t.Fatalf("want 9, got %d", v)
+		}
+	})
+	t.Run("4_0", func(t *testing.T) {
+		got := -1
+		async := Async2Ctx_4_0(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int) {
+			got = p0 + p1 + p2 + p3
+		})
+		f := async(context.Background(), 1, 2, 3, 4)
+		f()
+		if got != 10 {
+			t.Fatalf("want 10, got %d", got)
+		}
+	})
+	t.Run("4_1", func(t *testing.T) {
+		async := Async2Ctx_4_1(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int) int {
+			return p0 + p1 + p2 + p3 + 0
+		})
+		f0 := async(context.Background(), 1, 2, 3, 4)
+		if v := f0(); v != 10 {
+			t.Fatalf("want 10, got %d", v)
+		}
+	})
+	t.Run("4_2", func(t *testing.T) {
+		async := Async2Ctx_4_2(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int) (int, int) {
+			return p0 + p1 + p2 + p3 + 0, p0 + p1 + p2 + p3 + 1
+		})
+		f0, f1 := async(context.Background(), 1, 2, 3, 4)
+		if v := f0(); v != 10 {
+			t.Fatalf("want 10, got %d", v)
+		}
+		if v := f1(); v != 11 {
+			t.Fatalf("want 11, got %d", v)
+		}
+	})
+	t.Run("4_3", func(t *testing.T) {
+		async := Async2Ctx_4_3(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int) (int, int, int) {
+			return p0 + p1 + p2 + p3 + 0, p0 + p1 + p2 + p3 + 1, p0 + p1 + p2 + p3 + 2
+		})
+		f0, f1, f2 := async(context.Background(), 1, 2, 3, 4)
+		if v := f0(); v != 10 {
+			t.Fatalf("want 10, got %d", v)
+		}
+		if v := f1(); v != 11 {
+			t.Fatalf("want 11, got %d", v)
+		}
+		if v := f2(); v != 12 {
+			t.Fatalf("want 12, got %d", v)
+		}
+	})
+	t.Run("4_4", func(t *testing.T) {
+		async := Async2Ctx_4_4(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int) (int, int, int, int) {
+			return p0 + p1 + p2 + p3 + 0, p0 + p1 + p2 + p3 + 1, p0 + p1 + p2 + p3 + 2, p0 + p1 + p2 + p3 + 3
+		})
+		f0, f1, f2, f3 := async(context.Background(), 1, 2, 3, 4)
+		if v := f0(); v != 10 {
+			t.Fatalf("want 10, got %d", v)
+		}
+		if v := f1(); v != 11 {
+			t.Fatalf("want 11, got %d", v)
+		}
+		if v := f2(); v != 12 {
+			t.Fatalf("want 12, got %d", v)
+		}
+		if v := f3(); v != 13 {
+			t.Fatalf("want 13, got %d", v)
+		}
+	})
+	t.Run("5_0", func(t *testing.T) {
+		got := -1
+		async := Async2Ctx_5_0(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int) {
+			got = p0 + p1 + p2 + p3 + p4
+		})
+		f := async(context.Background(), 1, 2, 3, 4, 5)
+		f()
+		if got != 15 {
+			t.Fatalf("want 15, got %d", got)
+		}
+	})
+	t.Run("5_1", func(t *testing.T) {
+		async := Async2Ctx_5_1(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int) int {
+			return p0 + p1 + p2 + p3 + p4 + 0
+		})
+		f0 := async(context.Background(), 1, 2, 3, 4, 5)
+		if v := f0(); v != 15 {
+			t.Fatalf("want 15, got %d", v)
+		}
+	})
+	t.Run("5_2", func(t *testing.T) {
+		async := Async2Ctx_5_2(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int) (int, int) {
+			return p0 + p1 + p2 + p3 + p4 + 0, p0 + p1 + p2 + p3 + p4 + 1
+		})
+		f0, f1 := async(context.Background(), 1, 2, 3, 4, 5)
+		if v := f0(); v != 15 {
+			t.Fatalf("want 15, got %d", v)
+		}
+		if v := f1(); v != 16 {
+			t.Fatalf("want 16, got %d", v)
+		}
+	})
+	t.Run("5_3", func(t *testing.T) {
+		async := Async2Ctx_5_3(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int) (int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + 0, p0 + p1 + p2 + p3 + p4 + 1, p0 + p1 + p2 + p3 + p4 + 2
+		})
+		f0, f1, f2 := async(context.Background(), 1, 2, 3, 4, 5)
+		if v := f0(); v != 15 {
+			t.Fatalf("want 15, got %d", v)
+		}
+		if v := f1(); v != 16 {
+			t.Fatalf("want 16, got %d", v)
+		}
+		if v := f2(); v != 17 {
+			t.Fatalf("want 17, got %d", v)
+		}
+	})
+	t.Run("5_4", func(t *testing.T) {
+		async := Async2Ctx_5_4(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int) (int, int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + 0, p0 + p1 + p2 + p3 + p4 + 1, p0 + p1 + p2 + p3 + p4 + 2, p0 + p1 + p2 + p3 + p4 + 3
+		})
+		f0, f1, f2, f3 := async(context.Background(), 1, 2, 3, 4, 5)
+		if v := f0(); v != 15 {
+			t.Fatalf("want 15, got %d", v)
+		}
+		if v := f1(); v != 16 {
+			t.Fatalf("want 16, got %d", v)
+		}
+		if v := f2(); v != 17 {
+			t.Fatalf("want 17, got %d", v)
+		}
+		if v := f3(); v != 18 {
+			t.Fatalf("want 18, got %d", v)
+		}
+	})
+	t.Run("6_0", func(t *testing.T) {
+		got := -1
+		async := Async2Ctx_6_0(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int) {
+			got = p0 + p1 + p2 + p3 + p4 + p5
+		})
+		f := async(context.Background(), 1, 2, 3, 4, 5, 6)
+		f()
+		if got != 21 {
+			t.Fatalf("want 21, got %d", got)
+		}
+	})
+	t.Run("6_1", func(t *testing.T) {
+		async := Async2Ctx_6_1(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int) int {
+			return p0 + p1 + p2 + p3 + p4 + p5 + 0
+		})
+		f0 := async(context.Background(), 1, 2, 3, 4, 5, 6)
+		if v := f0(); v != 21 {
+			t.Fatalf("want 21, got %d", v)
+		}
+	})
+	t.Run("6_2", func(t *testing.T) {
+		async := Async2Ctx_6_2(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int) (int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + 0, p0 + p1 + p2 + p3 + p4 + p5 + 1
+		})
+		f0, f1 := async(context.Background(), 1, 2, 3, 4, 5, 6)
+		if v := f0(); v != 21 {
+			t.Fatalf("want 21, got %d", v)
+		}
+		if v := f1(); v != 22 {
+			t.Fatalf("want 22, got %d", v)
+		}
+	})
+	t.Run("6_3", func(t *testing.T) {
+		async := Async2Ctx_6_3(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int) (int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + 0, p0 + p1 + p2 + p3 + p4 + p5 + 1, p0 + p1 + p2 + p3 + p4 + p5 + 2
+		})
+		f0, f1, f2 := async(context.Background(), 1, 2, 3, 4, 5, 6)
+		if v := f0(); v != 21 {
+			t.Fatalf("want 21, got %d", v)
+		}
+		if v := f1(); v != 22 {
+			t.Fatalf("want 22, got %d", v)
+		}
+		if v := f2(); v != 23 {
+			t.Fatalf("want 23, got %d", v)
+		}
+	})
+	t.Run("6_4", func(t *testing.T) {
+		async := Async2Ctx_6_4(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int) (int, int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + 0, p0 + p1 + p2 + p3 + p4 + p5 + 1, p0 + p1 + p2 + p3 + p4 + p5 + 2, p0 + p1 + p2 + p3 + p4 + p5 + 3
+		})
+		f0, f1, f2, f3 := async(context.Background(), 1, 2, 3, 4, 5, 6)
+		if v := f0(); v != 21 {
+			t.Fatalf("want 21, got %d", v)
+		}
+		if v := f1(); v != 22 {
+			t.Fatalf("want 22, got %d", v)
+		}
+		if v := f2(); v != 23 {
+			t.Fatalf("want 23, got %d", v)
+		}
+		if v := f3(); v != 24 {
+			t.Fatalf("want 24, got %d", v)
+		}
+	})
+	t.Run("7_0", func(t *testing.T) {
+		got := -1
+		async := Async2Ctx_7_0(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int) {
+			got = p0 + p1 + p2 + p3 + p4 + p5 + p6
+		})
+		f := async(context.Background(), 1, 2, 3, 4, 5, 6, 7)
+		f()
+		if got != 28 {
+			t.Fatalf("want 28, got %d", got)
+		}
+	})
+	t.Run("7_1", func(t *testing.T) {
+		async := Async2Ctx_7_1(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int) int {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + 0
+		})
+		f0 := async(context.Background(), 1, 2, 3, 4, 5, 6, 7)
+		if v := f0(); v != 28 {
+			t.Fatalf("want 28, got %d", v)
+		}
+	})
+	t.Run("7_2", func(t *testing.T) {
+		async := Async2Ctx_7_2(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int) (int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + 0, p0 + p1 + p2 + p3 + p4 + p5 + p6 + 1
+		})
+		f0, f1 := async(context.Background(), 1, 2, 3, 4, 5, 6, 7)
+		if v := f0(); v != 28 {
+			t.Fatalf("want 28, got %d", v)
+		}
+		if v := f1(); v != 29 {
+			t.Fatalf("want 29, got %d", v)
+		}
+	})
+	t.Run("7_3", func(t *testing.T) {
+		async := Async2Ctx_7_3(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int) (int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + 0, p0 + p1 + p2 + p3 + p4 + p5 + p6 + 1, p0 + p1 + p2 + p3 + p4 + p5 + p6 + 2
+		})
+		f0, f1, f2 := async(context.Background(), 1, 2, 3, 4, 5, 6, 7)
+		if v := f0(); v != 28 {
+			t.Fatalf("want 28, got %d", v)
+		}
+		if v := f1(); v != 29 {
+			t.Fatalf("want 29, got %d", v)
+		}
+		if v := f2(); v != 30 {
+			t.Fatalf("want 30, got %d", v)
+		}
+	})
+	t.Run("7_4", func(t *testing.T) {
+		async := Async2Ctx_7_4(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int) (int, int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + 0, p0 + p1 + p2 + p3 + p4 + p5 + p6 + 1, p0 + p1 + p2 + p3 + p4 + p5 + p6 + 2, p0 + p1 + p2 + p3 + p4 + p5 + p6 + 3
+		})
+		f0, f1, f2, f3 := async(context.Background(), 1, 2, 3, 4, 5, 6, 7)
+		if v := f0(); v != 28 {
+			t.Fatalf("want 28, got %d", v)
+		}
+		if v := f1(); v != 29 {
+			t.Fatalf("want 29, got %d", v)
+		}
+		if v := f2(); v != 30 {
+			t.Fatalf("want 30, got %d", v)
+		}
+		if v := f3(); v != 31 {
+			t.Fatalf("want 31, got %d", v)
+		}
+	})
+	t.Run("8_0", func(t *testing.T) {
+		got := -1
+		async := Async2Ctx_8_0(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int, p7 int) {
+			got = p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7
+		})
+		f := async(context.Background(), 1, 2, 3, 4, 5, 6, 7, 8)
+		f()
+		if got != 36 {
+			t.Fatalf("want 36, got %d", got)
+		}
+	})
+	t.Run("8_1", func(t *testing.T) {
+		async := Async2Ctx_8_1(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int, p7 int) int {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 0
+		})
+		f0 := async(context.Background(), 1, 2, 3, 4, 5, 6, 7, 8)
+		if v := f0(); v != 36 {
+			t.Fatalf("want 36, got %d", v)
+		}
+	})
+	t.Run("8_2", func(t *testing.T) {
+		async := Async2Ctx_8_2(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int, p7 int) (int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 0, p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 1
+		})
+		f0, f1 := async(context.Background(), 1, 2, 3, 4, 5, 6, 7, 8)
+		if v := f0(); v != 36 {
+			t.Fatalf("want 36, got %d", v)
+		}
+		if v := f1(); v != 37 {
+			t.Fatalf("want 37, got %d", v)
+		}
+	})
+	t.Run("8_3", func(t *testing.T) {
+		async := Async2Ctx_8_3(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int, p7 int) (int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 0, p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 1, p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 2
+		})
+		f0, f1, f2 := async(context.Background(), 1, 2, 3, 4, 5, 6, 7, 8)
+		if v := f0(); v != 36 {
+			t.Fatalf("want 36, got %d", v)
+		}
+		if v := f1(); v != 37 {
+			t.Fatalf("want 37, got %d", v)
+		}
+		if v := f2(); v != 38 {
+			t.Fatalf("want 38, got %d", v)
+		}
+	})
+	t.Run("8_4", func(t *testing.T) {
+		async := Async2Ctx_8_4(func(ctx context.Context, p0 int, p1 int, p2 int, p3 int, p4 int, p5 int, p6 int, p7 int) (int, int, int, int) {
+			return p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 0, p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 1, p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 2, p0 + p1 + p2 + p3 + p4 + p5 + p6 + p7 + 3
+		})
+		f0, f1, f2, f3 := async(context.Background(), 1, 2, 3, 4, 5, 6, 7, 8)
+		if v := f0(); v != 36 {
+			t.Fatalf("want 36, got %d", v)
+		}
+		if v := f1(); v != 37 {
+			t.Fatalf("want 37, got %d", v)
+		}
+		if v := f2(); v != 38 {
+			t.Fatalf("want 38, got %d", v)
+		}
+		if v := f3(); v != 39 {
+			t.Fatalf("want 39, got %d", v)
+		}
+	})
+}
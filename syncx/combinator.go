@@ -0,0 +1,145 @@
+package syncx
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/llyb120/yoya/errx"
+)
+
+var (
+	// WithTimeout 等待超时
+	ErrTimeout = errors.New("syncx: future timeout")
+	// Any / Race 没有传入任何 Future 或 Result
+	ErrNoFuture = errors.New("syncx: no future")
+)
+
+// 组合函数都会立即在后台开始等待，不阻塞调用方，返回的 Future 可以重复获取结果
+// 与 Async2 系列一致，Then / All 中的 panic 被恢复，结果为零值
+
+// 在后台执行fn，返回等待结果的 Future
+func spawn[T any](fn func() T) Future[T] {
+	done := make(chan struct{})
+	var r T
+	go func() {
+		defer close(done)
+		defer handlePanic()
+		r = fn()
+	}()
+	return func() T {
+		<-done
+		return r
+	}
+}
+
+// 获取结果，panic 转为错误
+func try[T any](f Future[T]) (v T, err error) {
+	defer handlePanic(&err)
+	return f(), nil
+}
+
+// 结果就绪后用fn转换
+func Then[T any, R any](f Future[T], fn func(T) R) Future[R] {
+	return spawn(func() R {
+		return fn(f())
+	})
+}
+
+// 等待全部结果，按传入顺序返回
+func All[T any](fs ...Future[T]) Future[[]T] {
+	return spawn(func() []T {
+		var g Group
+		results := make([]T, len(fs))
+		for i, f := range fs {
+			i, f := i, f
+			g.Go(func() error {
+				results[i] = f()
+				return nil
+			})
+		}
+		g.Wait()
+		return results
+	})
+}
+
+type settled[T any] struct {
+	value T
+	err   error
+}
+
+// 并发获取所有结果，按完成顺序写入 channel
+func settle[T any](fs []Future[T]) <-chan settled[T] {
+	ch := make(chan settled[T], len(fs))
+	for _, f := range fs {
+		f := f
+		go func() {
+			v, err := try(f)
+			ch <- settled[T]{v, err}
+		}()
+	}
+	return ch
+}
+
+// 返回第一个没有错误的结果，全部失败时返回所有错误
+// Future 会自己恢复 panic，无法区分成功与失败，因此 Any 使用带错误的 Result
+func Any[T any](rs ...*Result[T]) *Result[T] {
+	return runResult(func() (T, error) {
+		var zero T
+		if len(rs) == 0 {
+			return zero, ErrNoFuture
+		}
+		ch := make(chan settled[T], len(rs))
+		for _, r := range rs {
+			r := r
+			go func() {
+				v, err := r.Get()
+				ch <- settled[T]{v, err}
+			}()
+		}
+		var errs errx.MultiError
+		for range rs {
+			s := <-ch
+			if s.err == nil {
+				return s.value, nil
+			}
+			errs.Add(s.err)
+		}
+		return zero, &errs
+	})
+}
+
+// 返回第一个完成的结果，无论成功与否
+func Race[T any](fs ...Future[T]) (Future[T], Future[error]) {
+	r := spawn(func() settled[T] {
+		if len(fs) == 0 {
+			return settled[T]{err: ErrNoFuture}
+		}
+		return <-settle(fs)
+	})
+	return split(r)
+}
+
+// 超过d仍未完成时返回 ErrTimeout，原任务不会被中止，需要中止时使用 Async2Ctx 系列
+func WithTimeout[T any](f Future[T], d time.Duration) (Future[T], Future[error]) {
+	ch := settle([]Future[T]{f})
+	timer := time.NewTimer(d)
+	r := spawn(func() settled[T] {
+		defer timer.Stop()
+		select {
+		case s := <-ch:
+			return s
+		case <-timer.C:
+			return settled[T]{err: fmt.Errorf("%w: %v", ErrTimeout, d)}
+		}
+	})
+	return split(r)
+}
+
+func split[T any](r Future[settled[T]]) (Future[T], Future[error]) {
+	return func() T {
+			return r().value
+		}, func() error {
+			return r().err
+		}
+}
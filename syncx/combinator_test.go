@@ -0,0 +1,89 @@
+package syncx
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func delay[T any](v T, d time.Duration) Future[T] {
+	return Async2_0_1(func() T {
+		time.Sleep(d)
+		return v
+	})()
+}
+
+// 测试：Then 和 All 不阻塞调用方，结果按顺序返回
+func TestThenAll(t *testing.T) {
+	start := time.Now()
+	f := Then(delay(1, 20*time.Millisecond), func(v int) string {
+		return string(rune('a' + v))
+	})
+	all := All(delay(1, 20*time.Millisecond), delay(2, 10*time.Millisecond), Mirai(3))
+	if time.Since(start) > 10*time.Millisecond {
+		t.Fatalf("want non-blocking combinators")
+	}
+	if v := f(); v != "b" {
+		t.Fatalf("want b, got %s", v)
+	}
+	values := all()
+	if len(values) != 3 || values[0] != 1 || values[1] != 2 || values[2] != 3 {
+		t.Fatalf("want [1 2 3], got %v", values)
+	}
+	// 重复获取结果
+	if v := f(); v != "b" {
+		t.Fatalf("want b, got %s", v)
+	}
+}
+
+// 测试：Any 跳过失败的 Result，Race 返回最先完成的结果
+func TestAnyRace(t *testing.T) {
+	fail := func() *Result[int] {
+		return AsyncResult(func() (int, error) {
+			return 0, errors.New("fail")
+		})
+	}
+	ok := func(v int, d time.Duration) *Result[int] {
+		return AsyncResult(func() (int, error) {
+			time.Sleep(d)
+			return v, nil
+		})
+	}
+	panics := AsyncResult(func() (int, error) { panic("boom") })
+	// 先失败的结果被跳过
+	if v, err := Any(fail(), panics, ok(2, 10*time.Millisecond), ok(3, 50*time.Millisecond)).Get(); v != 2 || err != nil {
+		t.Fatalf("want 2, got %v %v", v, err)
+	}
+	if _, err := Any(fail(), panics).Get(); err == nil {
+		t.Fatalf("want error when all failed")
+	}
+	if _, err := Any[int]().Get(); !errors.Is(err, ErrNoFuture) {
+		t.Fatalf("want ErrNoFuture, got %v", err)
+	}
+
+	boom := Future[int](func() int { panic("boom") })
+	v, err := Race(delay(1, 50*time.Millisecond), delay(2, 10*time.Millisecond))
+	if v() != 2 || err() != nil {
+		t.Fatalf("want 2, got %v %v", v(), err())
+	}
+	v, err = Race(boom, delay(2, 50*time.Millisecond))
+	if err() == nil {
+		t.Fatalf("want panic error from first completed")
+	}
+
+	if _, err = Race[int](); !errors.Is(err(), ErrNoFuture) {
+		t.Fatalf("want ErrNoFuture, got %v", err())
+	}
+}
+
+// 测试：WithTimeout 超时返回 ErrTimeout
+func TestWithTimeout(t *testing.T) {
+	v, err := WithTimeout(delay(1, 100*time.Millisecond), 10*time.Millisecond)
+	if !errors.Is(err(), ErrTimeout) || v() != 0 {
+		t.Fatalf("want ErrTimeout, got %v %v", v(), err())
+	}
+	v, err = WithTimeout(delay(1, time.Millisecond), time.Second)
+	if err() != nil || v() != 1 {
+		t.Fatalf("want 1, got %v %v", v(), err())
+	}
+}
@@ -0,0 +1,376 @@
+package syncx
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// 队列已满且拒绝策略为 RejectAbort
+	ErrRejected = errors.New("syncx: executor queue full")
+	// 执行器已经关闭
+	ErrShutdown = errors.New("syncx: executor shut down")
+)
+
+// 任务执行器，Group、Future、lsx.Map、objx.Walk 的异步任务都可以交给执行器运行
+type Executor interface {
+	// 提交任务，被拒绝时返回 ErrRejected，关闭后返回 ErrShutdown
+	Submit(task func()) error
+	// 不再接收新任务，等待已提交的任务执行完毕，ctx 结束时返回 ctx 的错误
+	Shutdown(ctx context.Context) error
+	Metrics() ExecutorMetrics
+}
+
+// 队列已满时的处理方式
+type RejectPolicy int
+
+const (
+	// 直接返回 ErrRejected
+	RejectAbort RejectPolicy = iota
+	// 在提交任务的协程中直接执行
+	RejectCallerRuns
+	// 阻塞到队列有空位
+	RejectBlock
+)
+
+type ExecutorOption struct {
+	// 工作协程数，弹性执行器中为常驻的协程数
+	Workers int
+	// 弹性执行器的最大协程数
+	MaxWorkers int
+	// 弹性执行器中超过 Workers 的协程空闲多久后退出，默认1分钟
+	IdleTimeout time.Duration
+	// 等待队列长度，小于等于0时任务只能直接交给空闲的协程
+	QueueSize int
+	Reject    RejectPolicy
+	// 任务 panic 时的回调，不设置时 panic 只计入 Metrics
+	OnPanic func(r any, stack []byte)
+}
+
+type ExecutorMetrics struct {
+	// 当前的工作协程数
+	Workers int
+	// 正在执行的任务数
+	Running int
+	// 排队中的任务数
+	Queued    int
+	Submitted int64
+	Completed int64
+	Rejected  int64
+	Panics    int64
+}
+
+type executorStats struct {
+	running   atomic.Int64
+	submitted atomic.Int64
+	completed atomic.Int64
+	rejected  atomic.Int64
+	panics    atomic.Int64
+}
+
+func (s *executorStats) run(task func(), onPanic func(any, []byte)) {
+	s.running.Add(1)
+	defer func() {
+		s.running.Add(-1)
+		s.completed.Add(1)
+		if r := recover(); r != nil {
+			s.panics.Add(1)
+			if onPanic != nil {
+				onPanic(r, debug.Stack())
+			}
+		}
+	}()
+	task()
+}
+
+func (s *executorStats) metrics() ExecutorMetrics {
+	return ExecutorMetrics{
+		Running:   int(s.running.Load()),
+		Submitted: s.submitted.Load(),
+		Completed: s.completed.Load(),
+		Rejected:  s.rejected.Load(),
+		Panics:    s.panics.Load(),
+	}
+}
+
+type poolExecutor struct {
+	opts    ExecutorOption
+	elastic bool
+	tasks   chan func()
+	// 读锁保护提交，写锁保护关闭，避免向已关闭的 channel 发送
+	mu     sync.RWMutex
+	closed bool
+	// 关闭时通知阻塞中的提交放弃等待
+	quit chan struct{}
+	// 阻塞等待队列空位的提交数，全部退出后才能关闭队列
+	blocked sync.WaitGroup
+	// 所有工作协程退出后关闭
+	done    chan struct{}
+	wg      sync.WaitGroup
+	workers atomic.Int64
+	idle    atomic.Int64
+	stats   executorStats
+}
+
+// 固定数量协程的执行器，Workers 小于等于0时使用1
+func NewFixedExecutor(opts ExecutorOption) Executor {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	e := newPoolExecutor(opts, false)
+	for i := 0; i < opts.Workers; i++ {
+		e.spawn(nil)
+	}
+	return e
+}
+
+// 弹性执行器，没有空闲协程时新建协程直到 MaxWorkers，超过 Workers 的协程空闲后退出
+func NewElasticExecutor(opts ExecutorOption) Executor {
+	if opts.Workers < 0 {
+		opts.Workers = 0
+	}
+	if opts.MaxWorkers < opts.Workers || opts.MaxWorkers <= 0 {
+		opts.MaxWorkers = opts.Workers
+	}
+	if opts.MaxWorkers <= 0 {
+		opts.MaxWorkers = 1
+	}
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = time.Minute
+	}
+	e := newPoolExecutor(opts, true)
+	for i := 0; i < opts.Workers; i++ {
+		e.spawn(nil)
+	}
+	return e
+}
+
+func newPoolExecutor(opts ExecutorOption, elastic bool) *poolExecutor {
+	queue := opts.QueueSize
+	if queue < 0 {
+		queue = 0
+	}
+	return &poolExecutor{
+		opts:    opts,
+		elastic: elastic,
+		tasks:   make(chan func(), queue),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+func (e *poolExecutor) Submit(task func()) error {
+	e.mu.RLock()
+	if e.closed {
+		e.mu.RUnlock()
+		e.stats.rejected.Add(1)
+		return ErrShutdown
+	}
+	e.stats.submitted.Add(1)
+	if e.offer(task) {
+		e.mu.RUnlock()
+		return nil
+	}
+	// 执行任务和阻塞等待时不能持有锁，否则 Shutdown 拿不到写锁，无法在 ctx 结束时返回
+	switch e.opts.Reject {
+	case RejectCallerRuns:
+		e.wg.Add(1)
+		e.mu.RUnlock()
+		defer e.wg.Done()
+		e.stats.run(task, e.opts.OnPanic)
+		return nil
+	case RejectBlock:
+		e.blocked.Add(1)
+		e.mu.RUnlock()
+		defer e.blocked.Done()
+		select {
+		case e.tasks <- task:
+			return nil
+		case <-e.quit:
+			e.stats.rejected.Add(1)
+			return ErrShutdown
+		}
+	}
+	e.mu.RUnlock()
+	e.stats.rejected.Add(1)
+	return ErrRejected
+}
+
+// 交给空闲的协程、放入队列或新建协程，都不行时返回false，调用方需持有读锁
+func (e *poolExecutor) offer(task func()) bool {
+	if e.elastic && e.idle.Load() == 0 && e.spawn(task) {
+		return true
+	}
+	select {
+	case e.tasks <- task:
+		return true
+	default:
+	}
+	// 空闲的协程可能刚好被其他任务占用
+	return e.elastic && e.spawn(task)
+}
+
+// 新建工作协程，弹性执行器达到 MaxWorkers 时返回false
+func (e *poolExecutor) spawn(first func()) bool {
+	max := int64(e.opts.Workers)
+	if e.elastic {
+		max = int64(e.opts.MaxWorkers)
+	}
+	for {
+		n := e.workers.Load()
+		if n >= max {
+			return false
+		}
+		if e.workers.CompareAndSwap(n, n+1) {
+			break
+		}
+	}
+	e.wg.Add(1)
+	go e.work(first)
+	return true
+}
+
+func (e *poolExecutor) work(task func()) {
+	defer e.wg.Done()
+	if task != nil {
+		e.stats.run(task, e.opts.OnPanic)
+	}
+	var timer *time.Timer
+	if e.elastic {
+		timer = time.NewTimer(e.opts.IdleTimeout)
+		defer timer.Stop()
+	}
+	for {
+		var timeout <-chan time.Time
+		if timer != nil {
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(e.opts.IdleTimeout)
+			timeout = timer.C
+		}
+		e.idle.Add(1)
+		select {
+		case task, ok := <-e.tasks:
+			e.idle.Add(-1)
+			if !ok {
+				e.workers.Add(-1)
+				return
+			}
+			e.stats.run(task, e.opts.OnPanic)
+		case <-timeout:
+			e.idle.Add(-1)
+			// 只回收超过常驻数量的协程
+			n := e.workers.Load()
+			if n > int64(e.opts.Workers) && e.workers.CompareAndSwap(n, n-1) {
+				return
+			}
+		}
+	}
+}
+
+func (e *poolExecutor) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.quit)
+		go func() {
+			// 阻塞中的提交退出后才能关闭队列，工作协程处理完队列中剩余的任务后退出
+			e.blocked.Wait()
+			close(e.tasks)
+			e.wg.Wait()
+			close(e.done)
+		}()
+	}
+	e.mu.Unlock()
+	select {
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *poolExecutor) Metrics() ExecutorMetrics {
+	m := e.stats.metrics()
+	m.Workers = int(e.workers.Load())
+	m.Queued = len(e.tasks)
+	return m
+}
+
+type callerRunsExecutor struct {
+	// 读锁保护提交，写锁保护关闭，保证关闭后不再调用 wg.Add
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+	stats  executorStats
+	// 任务 panic 时的回调
+	onPanic func(any, []byte)
+}
+
+// 在提交任务的协程中同步执行的执行器，适合测试或需要关闭并发的场景
+func NewCallerRunsExecutor(onPanic ...func(r any, stack []byte)) Executor {
+	e := &callerRunsExecutor{}
+	if len(onPanic) > 0 {
+		e.onPanic = onPanic[0]
+	}
+	return e
+}
+
+func (e *callerRunsExecutor) Submit(task func()) error {
+	e.mu.RLock()
+	if e.closed {
+		e.mu.RUnlock()
+		e.stats.rejected.Add(1)
+		return ErrShutdown
+	}
+	e.stats.submitted.Add(1)
+	e.wg.Add(1)
+	e.mu.RUnlock()
+	defer e.wg.Done()
+	e.stats.run(task, e.onPanic)
+	return nil
+}
+
+func (e *callerRunsExecutor) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	e.closed = true
+	e.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		e.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *callerRunsExecutor) Metrics() ExecutorMetrics {
+	m := e.stats.metrics()
+	m.Workers = m.Running
+	return m
+}
+
+// 在执行器中运行fn，被拒绝时 Future 返回零值
+func AsyncOn[T any](e Executor, fn func() T) Future[T] {
+	r := AsyncResultOn(e, func() (T, error) {
+		return fn(), nil
+	})
+	return r.Future()
+}
+
+// 在执行器中运行fn，被拒绝时 Result 返回执行器的错误
+func AsyncResultOn[T any](e Executor, fn func() (T, error)) *Result[T] {
+	return runResultOn(e, fn)
+}
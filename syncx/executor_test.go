@@ -0,0 +1,191 @@
+package syncx
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 测试：固定执行器的协程数和队列有上限，队列满时按策略拒绝
+func TestFixedExecutorReject(t *testing.T) {
+	e := NewFixedExecutor(ExecutorOption{Workers: 1, QueueSize: 1})
+	release := make(chan struct{})
+	started := make(chan struct{})
+	if err := e.Submit(func() { close(started); <-release }); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+	<-started
+	if err := e.Submit(func() { <-release }); err != nil {
+		t.Fatalf("want queued, got %v", err)
+	}
+	if err := e.Submit(func() {}); !errors.Is(err, ErrRejected) {
+		t.Fatalf("want ErrRejected, got %v", err)
+	}
+	m := e.Metrics()
+	if m.Workers != 1 || m.Running != 1 || m.Queued != 1 || m.Rejected != 1 {
+		t.Fatalf("want 1 worker 1 running 1 queued 1 rejected, got %+v", m)
+	}
+	close(release)
+	if err := e.Shutdown(context.Background()); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+	if m := e.Metrics(); m.Completed != 2 || m.Workers != 0 {
+		t.Fatalf("want 2 completed, got %+v", m)
+	}
+	if err := e.Submit(func() {}); !errors.Is(err, ErrShutdown) {
+		t.Fatalf("want ErrShutdown, got %v", err)
+	}
+}
+
+// 等待工作协程进入空闲状态，队列长度为0时任务只能交给空闲的协程
+func waitIdle(e Executor, n int64) {
+	for e.(*poolExecutor).idle.Load() < n {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// 测试：CallerRuns 策略在提交的协程中执行，panic 计入统计并回调
+func TestExecutorCallerRunsAndPanic(t *testing.T) {
+	var panics int32
+	e := NewFixedExecutor(ExecutorOption{
+		Workers: 1,
+		Reject:  RejectCallerRuns,
+		OnPanic: func(r any, stack []byte) {
+			atomic.AddInt32(&panics, 1)
+		},
+	})
+	waitIdle(e, 1)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	e.Submit(func() { close(started); <-release })
+	<-started
+	ran := false
+	e.Submit(func() { ran = true })
+	if !ran {
+		t.Fatalf("want task run in caller")
+	}
+	e.Submit(func() { panic("boom") })
+	close(release)
+	e.Shutdown(context.Background())
+	if atomic.LoadInt32(&panics) != 1 || e.Metrics().Panics != 1 {
+		t.Fatalf("want 1 panic, got %d", panics)
+	}
+}
+
+// 测试：Shutdown 超时返回 ctx 的错误
+func TestExecutorShutdownTimeout(t *testing.T) {
+	e := NewFixedExecutor(ExecutorOption{Workers: 1})
+	waitIdle(e, 1)
+	release := make(chan struct{})
+	defer close(release)
+	e.Submit(func() { <-release })
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := e.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want DeadlineExceeded, got %v", err)
+	}
+}
+
+// 测试：RejectBlock 阻塞中的提交不影响 Shutdown 按时返回，关闭后提交返回 ErrShutdown
+func TestExecutorShutdownWithBlockedSubmit(t *testing.T) {
+	e := NewFixedExecutor(ExecutorOption{Workers: 1, QueueSize: 1, Reject: RejectBlock})
+	waitIdle(e, 1)
+	release := make(chan struct{})
+	defer close(release)
+	e.Submit(func() { <-release })
+	e.Submit(func() {})
+	blocked := make(chan error, 1)
+	go func() {
+		blocked <- e.Submit(func() {})
+	}()
+	for e.Metrics().Submitted < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := e.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want DeadlineExceeded, got %v", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("want Shutdown to return by deadline, took %v", d)
+	}
+	select {
+	case err := <-blocked:
+		if !errors.Is(err, ErrShutdown) {
+			t.Fatalf("want ErrShutdown, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("want blocked Submit to return after Shutdown")
+	}
+}
+
+// 测试：弹性执行器按需扩容，空闲后回收到常驻数量
+func TestElasticExecutor(t *testing.T) {
+	e := NewElasticExecutor(ExecutorOption{Workers: 1, MaxWorkers: 3, IdleTimeout: 10 * time.Millisecond})
+	waitIdle(e, 1)
+	release := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		if err := e.Submit(func() { <-release }); err != nil {
+			t.Fatalf("want nil, got %v", err)
+		}
+	}
+	if n := e.Metrics().Workers; n != 3 {
+		t.Fatalf("want 3 workers, got %d", n)
+	}
+	if err := e.Submit(func() {}); !errors.Is(err, ErrRejected) {
+		t.Fatalf("want ErrRejected, got %v", err)
+	}
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for e.Metrics().Workers != 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if n := e.Metrics().Workers; n != 1 {
+		t.Fatalf("want 1 worker after idle, got %d", n)
+	}
+	e.Shutdown(context.Background())
+}
+
+// 测试：Group 和 Result 使用执行器运行任务
+func TestExecutorGroupResult(t *testing.T) {
+	e := NewFixedExecutor(ExecutorOption{Workers: 2, QueueSize: 8, Reject: RejectBlock})
+	defer e.Shutdown(context.Background())
+
+	var h Holder[int]
+	h.Set(1)
+	defer h.Del()
+	var g Group
+	g.SetExecutor(e)
+	var sum int32
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			atomic.AddInt32(&sum, int32(h.Get()))
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil || sum != 10 {
+		t.Fatalf("want 10, got %d %v", sum, err)
+	}
+
+	if v, err := AsyncResultOn(e, func() (int, error) { return 1, nil }).Get(); v != 1 || err != nil {
+		t.Fatalf("want 1, got %v %v", v, err)
+	}
+	if v := AsyncOn(NewCallerRunsExecutor(), func() int { return 2 })(); v != 2 {
+		t.Fatalf("want 2, got %d", v)
+	}
+
+	closed := NewCallerRunsExecutor()
+	closed.Shutdown(context.Background())
+	if err := AsyncResultOn(closed, func() (int, error) { return 1, nil }).Err(); !errors.Is(err, ErrShutdown) {
+		t.Fatalf("want ErrShutdown, got %v", err)
+	}
+	var g2 Group
+	g2.SetExecutor(closed)
+	g2.Go(func() error { return nil })
+	if err := g2.Wait(); err == nil {
+		t.Fatalf("want rejected error")
+	}
+}
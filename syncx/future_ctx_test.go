@@ -0,0 +1,72 @@
+package syncx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// 测试：取消ctx后任务退出，ctx已取消时任务不再执行
+func TestAsync2Ctx(t *testing.T) {
+	started := make(chan struct{})
+	wait := Async2Ctx_1_1(func(ctx context.Context, d time.Duration) error {
+		close(started)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d):
+			return nil
+		}
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	f := wait(ctx, 5*time.Second)
+	<-started
+	cancel()
+	if err := f(); err != context.Canceled {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+
+	called := false
+	f2 := Async2Ctx_0_0(func(ctx context.Context) {
+		called = true
+	})(ctx)
+	f2()
+	if called {
+		t.Fatalf("want task skipped after cancel")
+	}
+
+	sum := Async2Ctx_2_1(func(ctx context.Context, a, b int) int {
+		return a + b
+	})
+	if v := sum(context.Background(), 1, 2)(); v != 3 {
+		t.Fatalf("want 3, got %d", v)
+	}
+}
+
+// 测试：ctx已取消时任务不再执行，错误结果返回ctx的错误
+func TestAsync2CtxCanceledError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+	value, err := Async2Ctx_1_2(func(ctx context.Context, v int) (int, error) {
+		called = true
+		return v, nil
+	})(ctx, 1)
+	if e := err(); e != context.Canceled {
+		t.Fatalf("want context.Canceled, got %v", e)
+	}
+	if v := value(); v != 0 {
+		t.Fatalf("want 0, got %d", v)
+	}
+	if called {
+		t.Fatalf("want task skipped after cancel")
+	}
+
+	// 没有 error 类型的返回值时只返回零值
+	n := Async2Ctx_0_1(func(ctx context.Context) int {
+		return 1
+	})(ctx)
+	if v := n(); v != 0 {
+		t.Fatalf("want 0, got %d", v)
+	}
+}
@@ -165,23 +165,28 @@ func TestAsync2_1_1_DifferentTypes(t *testing.T) {
 
 // 测试：Async2_1_2 单参数返回值和错误
 func TestAsync2_1_2(t *testing.T) {
-	fn := func(x int, divisor int) (int, error) {
-		if divisor == 0 {
+	fn := func(x int) (int, error) {
+		if x == 0 {
 			return 0, errors.New("division by zero")
 		}
-		return x / divisor, nil
+		return 10 / x, nil
 	}
 	async := Async2_1_2(fn)
 
 	// 正常情况
-	f1, _ := async(10, 2)
+	f1, e1 := async(2)
 	if v := f1(); v != 5 {
 		t.Fatalf("want 5, got %v", v)
 	}
+	if err := e1(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
 
 	// 错误情况
-	f2, _ := async(10, 0)
-	_ = f2() // 应该处理错误，不 panic
+	_, e2 := async(0)
+	if err := e2(); err == nil {
+		t.Fatalf("want error, got nil")
+	}
 }
 
 // ==================== Async2_2_x 系列测试 ====================
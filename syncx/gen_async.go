@@ -0,0 +1,241 @@
+//go:build ignore
+
+// 生成 Async2_N_M 和 Async2Ctx_N_M 系列函数及测试
+// 使用：在 syncx 目录下执行 go generate
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+)
+
+const (
+	maxParams  = 8
+	maxResults = 4
+	header     = "// Code generated by gen_async.go; DO NOT EDIT.\n\n"
+)
+
+func main() {
+	write("async2_gen.go", genFuncs(false))
+	write("async2_ctx_gen.go", genFuncs(true))
+	write("async2_gen_test.go", genTests())
+}
+
+func write(name string, src []byte) {
+	out, err := format.Source(src)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n%s", name, err, src)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(name, out, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func names(prefix string, n int) []string {
+	s := make([]string, n)
+	for i := range s {
+		s[i] = fmt.Sprintf("%s%d", prefix, i)
+	}
+	return s
+}
+
+// 类型参数，例如 [P0, P1, R0 any, R1 any]
+func typeParams(n, m int) string {
+	var groups []string
+	head := names("P", n)
+	rs := names("R", m)
+	if m > 0 {
+		head = append(head, rs[0])
+		rs = rs[1:]
+	}
+	if len(head) > 0 {
+		groups = append(groups, strings.Join(head, ", ")+" any")
+	}
+	for _, r := range rs {
+		groups = append(groups, r+" any")
+	}
+	if len(groups) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(groups, ", ") + "]"
+}
+
+func results(m int) string {
+	switch m {
+	case 0:
+		return ""
+	case 1:
+		return " R0"
+	}
+	return " (" + strings.Join(names("R", m), ", ") + ")"
+}
+
+func futures(m int) string {
+	switch m {
+	case 0:
+		return "Future[any]"
+	case 1:
+		return "Future[R0]"
+	}
+	fs := make([]string, m)
+	for i := range fs {
+		fs[i] = fmt.Sprintf("Future[R%d]", i)
+	}
+	return "(" + strings.Join(fs, ", ") + ")"
+}
+
+func genFuncs(withCtx bool) []byte {
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("package syncx\n\nimport (\n")
+	if withCtx {
+		b.WriteString("\t\"context\"\n")
+	}
+	b.WriteString("\t\"sync\"\n)\n\n")
+	for n := 0; n <= maxParams; n++ {
+		for m := 0; m <= maxResults; m++ {
+			genFunc(&b, n, m, withCtx)
+		}
+	}
+	return b.Bytes()
+}
+
+func genFunc(b *bytes.Buffer, n, m int, withCtx bool) {
+	ps := names("P", n)
+	args := names("p", n)
+	in := ps
+	decl := make([]string, n)
+	for i := range decl {
+		decl[i] = args[i] + " " + ps[i]
+	}
+	call := args
+	name := "Async2"
+	if withCtx {
+		name = "Async2Ctx"
+		in = append([]string{"context.Context"}, in...)
+		decl = append([]string{"ctx context.Context"}, decl...)
+		call = append([]string{"ctx"}, call...)
+	}
+	fnType := fmt.Sprintf("func(%s)%s", strings.Join(in, ", "), results(m))
+	rets := names("r", m)
+	ptrs := make([]string, m)
+	for i := range ptrs {
+		ptrs[i] = "&" + rets[i]
+	}
+
+	fmt.Fprintf(b, "func %s_%d_%d%s(fn %s) func(%s) %s {\n", name, n, m, typeParams(n, m), fnType, strings.Join(in, ", "), futures(m))
+	fmt.Fprintf(b, "\treturn func(%s) %s {\n", strings.Join(decl, ", "), futures(m))
+	b.WriteString("\t\tvar wg sync.WaitGroup\n\t\twg.Add(1)\n")
+	for i, r := range rets {
+		fmt.Fprintf(b, "\t\tvar %s R%d\n", r, i)
+	}
+	b.WriteString("\t\tgo func() {\n\t\t\tdefer wg.Done()\n")
+	fmt.Fprintf(b, "\t\t\tdefer handlePanic(%s)\n", strings.Join(ptrs, ", "))
+	invoke := fmt.Sprintf("fn(%s)", strings.Join(call, ", "))
+	if m > 0 {
+		invoke = strings.Join(rets, ", ") + " = " + invoke
+	}
+	if withCtx {
+		fmt.Fprintf(b, "\t\t\trunCtx(ctx, func(ctx context.Context) {\n\t\t\t\t%s\n\t\t\t}%s)\n", invoke, strings.Join(append([]string{""}, ptrs...), ", "))
+	} else {
+		fmt.Fprintf(b, "\t\t\t%s\n", invoke)
+	}
+	b.WriteString("\t\t}()\n")
+	if m == 0 {
+		b.WriteString("\t\treturn func() any {\n\t\t\twg.Wait()\n\t\t\treturn nil\n\t\t}\n")
+	} else {
+		fs := make([]string, m)
+		for i := range fs {
+			fs[i] = fmt.Sprintf("func() R%d {\n\t\t\twg.Wait()\n\t\t\treturn r%d\n\t\t}", i, i)
+		}
+		fmt.Fprintf(b, "\t\treturn %s\n", strings.Join(fs, ", "))
+	}
+	b.WriteString("\t}\n}\n\n")
+}
+
+// 每个函数的参数为 1..n，第i个返回值为参数之和加i
+func genTests() []byte {
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("package syncx\n\nimport (\n\t\"context\"\n\t\"testing\"\n)\n\n")
+	for _, withCtx := range []bool{false, true} {
+		name := "Async2"
+		if withCtx {
+			name = "Async2Ctx"
+		}
+		fmt.Fprintf(&b, "// 测试：%s 系列的参数和返回值一一对应\n", name)
+		fmt.Fprintf(&b, "func Test%sMatrix(t *testing.T) {\n", name)
+		for n := 0; n <= maxParams; n++ {
+			for m := 0; m <= maxResults; m++ {
+				genTest(&b, name, n, m, withCtx)
+			}
+		}
+		b.WriteString("}\n\n")
+	}
+	return b.Bytes()
+}
+
+func genTest(b *bytes.Buffer, name string, n, m int, withCtx bool) {
+	args := names("p", n)
+	decl := make([]string, n)
+	for i := range decl {
+		decl[i] = args[i] + " int"
+	}
+	values := make([]string, n)
+	sum := 0
+	for i := range values {
+		values[i] = fmt.Sprint(i + 1)
+		sum += i + 1
+	}
+	if withCtx {
+		decl = append([]string{"ctx context.Context"}, decl...)
+		values = append([]string{"context.Background()"}, values...)
+	}
+	expr := "0"
+	if n > 0 {
+		expr = strings.Join(args, " + ")
+	}
+	fmt.Fprintf(b, "\tt.Run(\"%d_%d\", func(t *testing.T) {\n", n, m)
+	typ := make([]string, m)
+	for i := range typ {
+		typ[i] = "int"
+	}
+	rs := ""
+	switch {
+	case m == 1:
+		rs = " int"
+	case m > 1:
+		rs = " (" + strings.Join(typ, ", ") + ")"
+	}
+	if m == 0 {
+		b.WriteString("\t\tgot := -1\n")
+	}
+	fmt.Fprintf(b, "\t\tasync := %s_%d_%d(func(%s)%s {\n", name, n, m, strings.Join(decl, ", "), rs)
+	if m == 0 {
+		fmt.Fprintf(b, "\t\t\tgot = %s\n", expr)
+	} else {
+		outs := make([]string, m)
+		for i := range outs {
+			outs[i] = fmt.Sprintf("%s + %d", expr, i)
+		}
+		fmt.Fprintf(b, "\t\t\treturn %s\n", strings.Join(outs, ", "))
+	}
+	b.WriteString("\t\t})\n")
+	fs := names("f", m)
+	if m == 0 {
+		fs = []string{"f"}
+	}
+	fmt.Fprintf(b, "\t\t%s := async(%s)\n", strings.Join(fs, ", "), strings.Join(values, ", "))
+	if m == 0 {
+		fmt.Fprintf(b, "\t\tf()\n\t\tif got != %d {\n\t\t\tt.Fatalf(\"want %d, got %%d\", got)\n\t\t}\n", sum, sum)
+	}
+	for i := 0; i < m; i++ {
+		fmt.Fprintf(b, "\t\tif v := f%d(); v != %d {\n\t\t\tt.Fatalf(\"want %d, got %%d\", v)\n\t\t}\n", i, sum+i, sum+i)
+	}
+	b.WriteString("\t})\n")
+}
@@ -1,6 +1,8 @@
 package syncx
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/llyb120/yoya/internal"
 	"runtime"
@@ -13,35 +15,209 @@ import (
 )
 
 type Group struct {
-	wg    sync.WaitGroup
-	eg    errx.MultiError
-	limit int
+	wg sync.WaitGroup
+	eg errx.MultiError
+	// 并发上限及正在运行的任务数，limit 小于等于0时不限制
+	mu     sync.Mutex
+	cond   *sync.Cond
+	limit  int
+	active int
+	// 传给任务的ctx，出错或等待超时时取消
+	once          sync.Once
+	ctx           context.Context
+	cancel        context.CancelCauseFunc
+	cancelOnError bool
+	bound         bool
+	executor      Executor
+	// 启动任务前等待的信号量和限流器
+	sem     Semaphore
+	limiter RateLimiter
+}
+
+// 创建绑定到 ctx 的协程组，任何一个任务出错、Wait 超时或 Wait 返回时取消派生的ctx
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	g := &Group{cancelOnError: true, bound: true}
+	g.once.Do(func() {
+		g.ctx, g.cancel = context.WithCancelCause(ctx)
+	})
+	return g, g.ctx
+}
+
+func (g *Group) init() {
+	g.once.Do(func() {
+		g.ctx, g.cancel = context.WithCancelCause(context.Background())
+	})
+}
+
+// 设置任务出错时是否取消ctx，WithContext 创建的协程组默认取消，需要在 Go 之前调用
+func (g *Group) SetCancelOnError(cancel bool) {
+	g.cancelOnError = cancel
 }
 
 var globalGroupHolder = stlx.NewSyncBimMap[int64, int64]()
 
 func (g *Group) Go(fn func() error) {
+	g.GoCtx(func(ctx context.Context) error {
+		return fn()
+	})
+}
+
+// 启动带ctx的任务，任务应在ctx取消后尽快返回
+// 设置了并发上限时，运行中的任务数达到上限后阻塞到有任务结束
+// 设置了信号量或限流器时先等待，ctx取消导致没有启动的任务，其错误由 Wait 返回
+func (g *Group) GoCtx(fn func(ctx context.Context) error) {
+	g.acquire(true)
+	free, err := g.throttle(true)
+	if err != nil {
+		g.release()
+		g.fail(err)
+		return
+	}
+	g.start(fn, free)
+}
+
+// 运行中的任务数未达到上限时启动任务并返回true，否则直接返回false
+func (g *Group) TryGo(fn func() error) bool {
+	return g.TryGoCtx(func(ctx context.Context) error {
+		return fn()
+	})
+}
+
+func (g *Group) TryGoCtx(fn func(ctx context.Context) error) bool {
+	if !g.acquire(false) {
+		return false
+	}
+	free, err := g.throttle(false)
+	if err != nil {
+		g.release()
+		return false
+	}
+	g.start(fn, free)
+	return true
+}
+
+// 占用一个并发名额，block 为false时名额已满直接返回false
+func (g *Group) acquire(block bool) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for g.limit > 0 && g.active >= g.limit {
+		if !block {
+			return false
+		}
+		if g.cond == nil {
+			g.cond = sync.NewCond(&g.mu)
+		}
+		g.cond.Wait()
+	}
+	g.active++
+	return true
+}
+
+var errThrottled = errors.New("syncx: throttled")
+
+// 按信号量和限流器等待启动任务，返回任务结束时归还信号量的函数
+// block 为false时不等待，无法立即启动时返回 errThrottled
+func (g *Group) throttle(block bool) (func(), error) {
+	g.init()
+	free := func() {}
+	if g.sem != nil {
+		if block {
+			if err := g.sem.Acquire(g.ctx, 1); err != nil {
+				return nil, err
+			}
+		} else if !g.sem.TryAcquire(1) {
+			return nil, errThrottled
+		}
+		sem := g.sem
+		free = func() { sem.Release(1) }
+	}
+	if g.limiter != nil {
+		var err error
+		if block {
+			err = g.limiter.Wait(g.ctx)
+		} else if !g.limiter.Allow() {
+			err = errThrottled
+		}
+		if err != nil {
+			free()
+			return nil, err
+		}
+	}
+	return free, nil
+}
+
+func (g *Group) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.active--
+	if g.cond != nil {
+		g.cond.Signal()
+	}
+}
+
+func (g *Group) start(fn func(ctx context.Context) error, free func()) {
+	g.init()
 	g.wg.Add(1)
 	var parentGoid = goid.Get()
-	go func() {
+	task := func() {
 		defer g.wg.Done()
+		defer g.release()
+		defer free()
 		defer func() {
 			if r := recover(); r != nil {
 				stack := make([]byte, 4096)
 				stackLen := runtime.Stack(stack, false)
-				g.eg.Add(fmt.Errorf("panic: %v\nstack: %s", r, stack[:stackLen]))
+				g.fail(fmt.Errorf("panic: %v\nstack: %s", r, stack[:stackLen]))
 			}
 		}()
-		// 存储协程id
+		// 存储协程id，执行器在当前协程中直接运行任务时不需要记录
 		localGoid := goid.Get()
-		globalGroupHolder.Set(localGoid, parentGoid)
-		defer globalGroupHolder.Del(localGoid)
+		if localGoid != parentGoid {
+			globalGroupHolder.Set(localGoid, parentGoid)
+			defer globalGroupHolder.Del(localGoid)
+			// 清理任务在 Holder 中留下的值，执行器的工作协程会被复用
+			defer runGoroutineExit(localGoid)
+		}
 		// 调用
-		err := fn()
+		err := fn(g.ctx)
 		if err != nil {
-			g.eg.Add(err)
+			g.fail(err)
 		}
-	}()
+	}
+	if g.executor == nil {
+		go task()
+		return
+	}
+	if err := g.executor.Submit(task); err != nil {
+		g.wg.Done()
+		g.release()
+		free()
+		g.fail(err)
+	}
+}
+
+// 设置运行任务的执行器，不设置时每个任务启动一个新的协程，需要在 Go 之前调用
+// 执行器拒绝的任务不会运行，拒绝的错误由 Wait 返回
+func (g *Group) SetExecutor(e Executor) {
+	g.executor = e
+}
+
+// 设置任务共用的信号量，每个任务启动前获取1个名额，结束后归还，需要在 Go 之前调用
+// 与 SetLimit 不同，同一个信号量可以在多个协程组之间共享
+func (g *Group) SetSemaphore(s Semaphore) {
+	g.sem = s
+}
+
+// 设置启动任务的限流器，Go 等待到限流器允许后启动任务，TryGo 被限流时返回false，需要在 Go 之前调用
+func (g *Group) SetRateLimiter(l RateLimiter) {
+	g.limiter = l
+}
+
+func (g *Group) fail(err error) {
+	g.eg.Add(err)
+	if g.cancelOnError {
+		g.cancel(err)
+	}
 }
 
 func (g *Group) Wait(timeout ...time.Duration) error {
@@ -51,14 +227,22 @@ func (g *Group) Wait(timeout ...time.Duration) error {
 	return g.waitWithTimeout(0)
 }
 
+// 设置同时运行的任务数上限，小于等于0时不限制，可以在运行中修改
+// 调小上限不会中止已经在运行的任务，只影响之后启动的任务
 func (g *Group) SetLimit(limit int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
 	g.limit = limit
+	if g.cond != nil {
+		g.cond.Broadcast()
+	}
 }
 
 func (g *Group) waitWithTimeout(timeout time.Duration) error {
 	if timeout <= 0 {
 		// 无超时，直接等待
 		g.wg.Wait()
+		g.done()
 		if g.eg.HasError() {
 			return &g.eg
 		}
@@ -75,12 +259,24 @@ func (g *Group) waitWithTimeout(timeout time.Duration) error {
 	// 等待结果或超时
 	select {
 	case <-done:
+		g.done()
 		if g.eg.HasError() {
 			return &g.eg
 		}
 		return nil
 	case <-time.After(timeout):
-		return fmt.Errorf("等待超时，超过 %v", timeout)
+		err := fmt.Errorf("等待超时，超过 %v", timeout)
+		// 通知仍在运行的任务退出
+		g.init()
+		g.cancel(err)
+		return err
+	}
+}
+
+// 所有任务结束，WithContext 创建的ctx随之取消
+func (g *Group) done() {
+	if g.bound {
+		g.cancel(context.Canceled)
 	}
 }
 
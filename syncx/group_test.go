@@ -1,7 +1,9 @@
 package syncx
 
 import (
+	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -24,3 +26,200 @@ func TestGroup(t *testing.T) {
 		t.Errorf("Expected error string, got %s", err.Error())
 	}
 }
+
+// 测试：WithContext 创建的协程组在第一个错误时取消ctx
+func TestGroupWithContext(t *testing.T) {
+	g, ctx := WithContext(context.Background())
+	g.GoCtx(func(ctx context.Context) error {
+		return fmt.Errorf("error 1")
+	})
+	g.GoCtx(func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(5 * time.Second):
+			return fmt.Errorf("not canceled")
+		}
+	})
+	err := g.Wait()
+	if err == nil || err.Error() != "error 1" {
+		t.Fatalf("want error 1, got %v", err)
+	}
+	if ctx.Err() == nil {
+		t.Fatalf("want ctx canceled")
+	}
+	if cause := context.Cause(ctx); cause == nil || cause.Error() != "error 1" {
+		t.Fatalf("want cause error 1, got %v", cause)
+	}
+}
+
+// 测试：关闭出错取消后其他任务继续执行
+func TestGroupContinueOnError(t *testing.T) {
+	g, _ := WithContext(context.Background())
+	g.SetCancelOnError(false)
+	g.Go(func() error {
+		return fmt.Errorf("error 1")
+	})
+	g.GoCtx(func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		return ctx.Err()
+	})
+	err := g.Wait()
+	if err == nil || err.Error() != "error 1" {
+		t.Fatalf("want error 1, got %v", err)
+	}
+}
+
+// 测试：Wait 超时后任务的ctx被取消
+func TestGroupWaitTimeoutCancel(t *testing.T) {
+	var g Group
+	exited := make(chan struct{})
+	g.GoCtx(func(ctx context.Context) error {
+		<-ctx.Done()
+		close(exited)
+		return nil
+	})
+	if err := g.Wait(10 * time.Millisecond); err == nil {
+		t.Fatalf("want timeout error")
+	}
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatalf("want task canceled after timeout")
+	}
+}
+
+// 测试：SetLimit 限制同时运行的任务数
+func TestGroupLimit(t *testing.T) {
+	var g Group
+	g.SetLimit(2)
+	var running, peak int32
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatalf("want nil, got %v", err)
+	}
+	if peak != 2 {
+		t.Fatalf("want peak 2, got %d", peak)
+	}
+}
+
+// 测试：达到上限时 TryGo 返回false，调大上限后阻塞的 Go 继续执行
+func TestGroupTryGo(t *testing.T) {
+	var g Group
+	g.SetLimit(1)
+	release := make(chan struct{})
+	if !g.TryGo(func() error { <-release; return nil }) {
+		t.Fatalf("want first TryGo ok")
+	}
+	if g.TryGo(func() error { return nil }) {
+		t.Fatalf("want TryGo rejected at limit")
+	}
+
+	started := make(chan struct{})
+	go g.Go(func() error { close(started); return nil })
+	select {
+	case <-started:
+		t.Fatalf("want Go blocked at limit")
+	case <-time.After(20 * time.Millisecond):
+	}
+	g.SetLimit(2)
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatalf("want Go started after raising limit")
+	}
+	close(release)
+	g.Wait()
+}
+
+// 测试：限流时子协程仍能读取父协程 Holder 中的值
+func TestGroupLimitHolder(t *testing.T) {
+	var h Holder[int]
+	h.Set(1)
+	defer h.Del()
+	var g Group
+	g.SetLimit(1)
+	var sum int32
+	for i := 0; i < 3; i++ {
+		g.Go(func() error {
+			atomic.AddInt32(&sum, int32(h.Get()))
+			return nil
+		})
+	}
+	g.Wait()
+	if sum != 3 {
+		t.Fatalf("want 3, got %d", sum)
+	}
+}
+
+// 测试：多个协程组共享信号量，总并发不超过名额
+func TestGroupSemaphore(t *testing.T) {
+	sem := NewSemaphore(2)
+	var running, peak atomic.Int64
+	var g1, g2 Group
+	g1.SetSemaphore(sem)
+	g2.SetSemaphore(sem)
+	task := func() error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	}
+	for i := 0; i < 5; i++ {
+		g1.Go(task)
+		g2.Go(task)
+	}
+	g1.Wait()
+	g2.Wait()
+	if p := peak.Load(); p > 2 {
+		t.Fatalf("want at most 2, got %d", p)
+	}
+	if !sem.TryAcquire(2) {
+		t.Fatalf("want all released")
+	}
+}
+
+// 测试：限流器控制任务的启动，TryGo 被限流时返回false
+func TestGroupRateLimiter(t *testing.T) {
+	clock := newFakeClock()
+	var g Group
+	g.SetRateLimiter(NewTokenBucket(RateLimiterOption{Rate: 1, Per: time.Second, Clock: clock}))
+	var n atomic.Int64
+	if !g.TryGo(func() error { n.Add(1); return nil }) {
+		t.Fatalf("want started")
+	}
+	if g.TryGo(func() error { n.Add(1); return nil }) {
+		t.Fatalf("want throttled")
+	}
+	started := make(chan struct{})
+	go func() {
+		g.Go(func() error { n.Add(1); return nil })
+		close(started)
+	}()
+	<-clock.waiting
+	clock.Advance(time.Second)
+	<-started
+	g.Wait()
+	if v := n.Load(); v != 2 {
+		t.Fatalf("want 2, got %d", v)
+	}
+}
@@ -6,14 +6,39 @@ import (
 	"github.com/petermattis/goid"
 )
 
+// 子协程读取父协程值的方式
+type InheritPolicy int
+
+const (
+	// 子协程与父协程共享同一个值（默认）
+	InheritShare InheritPolicy = iota
+	// 子协程首次读取时深拷贝父协程的值，之后各自修改互不影响
+	InheritClone
+	// 子协程不继承父协程的值，按 InitFunc 初始化
+	InheritIsolated
+)
+
 type Holder[V any] struct {
 	*sync.RWMutex
 	mp       map[int64]V
 	once     sync.Once
 	InitFunc func() V
+	// 继承方式，默认共享
+	Inherit InheritPolicy
+	// InheritClone 时使用的拷贝函数，必须设置，例如 objx.MustDeepClone[V]
+	CloneFunc func(V) V
+}
+
+// 跨协程传递的值，由 Snapshot 取得，在其他协程中 Restore
+type HolderSnapshot[V any] struct {
+	value V
+	ok    bool
 }
 
 func (h *Holder[V]) init() {
+	if h.Inherit == InheritClone && h.CloneFunc == nil {
+		panic("syncx: Holder with InheritClone requires CloneFunc, e.g. objx.MustDeepClone[V]")
+	}
 	h.once.Do(func() {
 		h.RWMutex = &sync.RWMutex{}
 		h.mp = make(map[int64]V)
@@ -22,44 +47,120 @@ func (h *Holder[V]) init() {
 
 func (h *Holder[V]) Get() V {
 	h.init()
-	h.RLock()
 	goid := goid.Get()
-	if item, ok := h.mp[goid]; ok {
-		h.RUnlock()
+	if item, ok := h.load(goid); ok {
 		return item
-	} else {
-		// 如果本协程没有，尝试去父协程找
-		targetGoid := goid
-		for {
-			parentGoid, ok := globalGroupHolder.Get(targetGoid)
-			if !ok {
-				break
-			}
-			targetGoid = parentGoid
-			// 如果可以在父协程找到
-			if item, ok := h.mp[targetGoid]; ok {
-				return item
-			}
-		}
 	}
-	h.RUnlock()
 	if h.InitFunc == nil {
 		var zero V
 		return zero
 	}
 	item := h.InitFunc()
 	h.Lock()
-	h.mp[goid] = item
+	h.store(goid, item)
 	h.Unlock()
 	return item
 }
 
+// 读取当前协程可见的值，按继承方式处理父协程的值
+func (h *Holder[V]) load(goid int64) (V, bool) {
+	h.RLock()
+	item, ok := h.mp[goid]
+	if ok || h.Inherit == InheritIsolated {
+		h.RUnlock()
+		return item, ok
+	}
+	// 如果本协程没有，尝试去父协程找
+	targetGoid := goid
+	for {
+		parentGoid, found := globalGroupHolder.Get(targetGoid)
+		if !found {
+			break
+		}
+		targetGoid = parentGoid
+		// 如果可以在父协程找到
+		if item, ok = h.mp[targetGoid]; ok {
+			break
+		}
+	}
+	h.RUnlock()
+	if !ok || h.Inherit != InheritClone {
+		return item, ok
+	}
+	// 拷贝后保存为本协程的值，之后的读取和修改都在副本上进行
+	item = h.CloneFunc(item)
+	h.Lock()
+	defer h.Unlock()
+	if own, exists := h.mp[goid]; exists {
+		return own, true
+	}
+	h.store(goid, item)
+	return item, true
+}
+
 func (h *Holder[V]) Set(value V) {
 	h.init()
 	h.Lock()
 	defer h.Unlock()
 	goid := goid.Get()
-	h.mp[goid] = value
+	h.store(goid, value)
+}
+
+// 在当前协程设置value并执行fn，结束后恢复原来的值，原来没有值时删除
+func (h *Holder[V]) With(value V, fn func()) {
+	defer h.Restore(HolderSnapshot[V]{value: value, ok: true})()
+	fn()
+}
+
+// 取得当前协程可见的值（包括按继承方式从父协程取得的值），用于传递给不是由 Group 启动的协程
+func (h *Holder[V]) Snapshot() HolderSnapshot[V] {
+	h.init()
+	value, ok := h.load(goid.Get())
+	return HolderSnapshot[V]{value: value, ok: ok}
+}
+
+// 在当前协程恢复快照中的值，返回的函数用于还原当前协程原来的值
+// 典型用法是在工作池的任务中 defer h.Restore(snap)()
+func (h *Holder[V]) Restore(snap HolderSnapshot[V]) func() {
+	h.init()
+	goid := goid.Get()
+	h.Lock()
+	prev, existed := h.mp[goid]
+	if snap.ok {
+		h.store(goid, snap.value)
+	} else {
+		delete(h.mp, goid)
+	}
+	h.Unlock()
+	return func() {
+		h.Lock()
+		defer h.Unlock()
+		if existed {
+			h.mp[goid] = prev
+		} else {
+			delete(h.mp, goid)
+		}
+	}
+}
+
+// 当前持有值的协程数量，用于排查泄漏
+func (h *Holder[V]) Len() int {
+	h.init()
+	h.RLock()
+	defer h.RUnlock()
+	return len(h.mp)
+}
+
+// 返回协程id到值的快照，仅用于调试
+func (h *Holder[V]) Dump() map[int64]V {
+	h.init()
+	h.RLock()
+	defer h.RUnlock()
+	mp := make(map[int64]V, len(h.mp))
+	for k, v := range h.mp {
+		mp[k] = v
+	}
+	return mp
 }
 
 func (h *Holder[V]) Del() V {
@@ -71,3 +172,44 @@ func (h *Holder[V]) Del() V {
 	delete(h.mp, goid)
 	return value
 }
+
+// 写入值，如果当前协程由 Group 启动，协程结束时自动删除
+// 调用方需持有写锁
+func (h *Holder[V]) store(goid int64, value V) {
+	h.mp[goid] = value
+	if _, ok := globalGroupHolder.Get(goid); ok {
+		onGoroutineExit(goid, h, func() {
+			h.Lock()
+			delete(h.mp, goid)
+			h.Unlock()
+		})
+	}
+}
+
+// Group 启动的协程退出时需要执行的清理函数，按协程id和 Holder 去重
+var exitHooks = struct {
+	sync.Mutex
+	mp map[int64]map[any]func()
+}{mp: make(map[int64]map[any]func())}
+
+func onGoroutineExit(goid int64, key any, fn func()) {
+	exitHooks.Lock()
+	defer exitHooks.Unlock()
+	hooks, ok := exitHooks.mp[goid]
+	if !ok {
+		hooks = make(map[any]func())
+		exitHooks.mp[goid] = hooks
+	}
+	hooks[key] = fn
+}
+
+// 协程退出时调用，清理该协程在所有 Holder 中的值
+func runGoroutineExit(goid int64) {
+	exitHooks.Lock()
+	hooks := exitHooks.mp[goid]
+	delete(exitHooks.mp, goid)
+	exitHooks.Unlock()
+	for _, fn := range hooks {
+		fn()
+	}
+}
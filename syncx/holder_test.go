@@ -1,8 +1,14 @@
 package syncx
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"runtime"
+	"strconv"
 	"testing"
+
+	"github.com/petermattis/goid"
 )
 
 func TestHolder(t *testing.T) {
@@ -27,3 +33,175 @@ func TestHolder(t *testing.T) {
 	fmt.Println(holder.Get())
 
 }
+
+// goid 不支持当前 Go 版本时取得的id与运行时不一致，依赖协程隔离的测试无法进行
+func skipWithoutGoid(t *testing.T) {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	id, _ := strconv.ParseInt(string(bytes.Fields(buf)[1]), 10, 64)
+	if id != goid.Get() {
+		t.Skip("goid is not supported on this Go version")
+	}
+}
+
+// 测试：With 执行期间使用新值，结束后恢复原值
+func TestHolderWith(t *testing.T) {
+	var holder Holder[int]
+	holder.Set(1)
+	holder.With(2, func() {
+		if v := holder.Get(); v != 2 {
+			t.Fatalf("want 2, got %d", v)
+		}
+	})
+	if v := holder.Get(); v != 1 {
+		t.Fatalf("want 1, got %d", v)
+	}
+	holder.Del()
+
+	// 原来没有值时结束后删除
+	holder.With(3, func() {})
+	if n := holder.Len(); n != 0 {
+		t.Fatalf("want 0, got %d", n)
+	}
+}
+
+// 测试：Group 启动的协程结束后自动清理 Holder 中的值
+func TestHolderCleanupOnGroupExit(t *testing.T) {
+	skipWithoutGoid(t)
+	var holder Holder[int]
+	holder.Set(1)
+	defer holder.Del()
+
+	var g Group
+	for i := 0; i < 10; i++ {
+		i := i
+		g.Go(func() error {
+			holder.Set(i)
+			return nil
+		})
+	}
+	g.Wait()
+	if n := holder.Len(); n != 1 {
+		t.Fatalf("want 1, got %d", n)
+	}
+
+	// 执行器的工作协程同样会被清理
+	e := NewFixedExecutor(ExecutorOption{Workers: 2, QueueSize: 10})
+	defer e.Shutdown(context.Background())
+	g.SetExecutor(e)
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			holder.Set(2)
+			return nil
+		})
+	}
+	g.Wait()
+	if dump := holder.Dump(); len(dump) != 1 || dump[goid.Get()] != 1 {
+		t.Fatalf("want only current goroutine, got %v", dump)
+	}
+}
+
+// 测试：三种继承方式下子协程读取到的值
+func TestHolderInherit(t *testing.T) {
+	skipWithoutGoid(t)
+	// 共享：子协程修改会影响父协程
+	share := Holder[map[string]int]{}
+	share.Set(map[string]int{"a": 1})
+	defer share.Del()
+	var g Group
+	g.Go(func() error {
+		share.Get()["a"] = 2
+		return nil
+	})
+	g.Wait()
+	if v := share.Get()["a"]; v != 2 {
+		t.Fatalf("want 2, got %d", v)
+	}
+
+	// 拷贝：子协程修改的是自己的副本
+	clone := Holder[map[string]int]{
+		Inherit: InheritClone,
+		CloneFunc: func(m map[string]int) map[string]int {
+			cp := make(map[string]int, len(m))
+			for k, v := range m {
+				cp[k] = v
+			}
+			return cp
+		},
+	}
+	clone.Set(map[string]int{"a": 1})
+	defer clone.Del()
+	g.Go(func() error {
+		clone.Get()["a"] = 2
+		if v := clone.Get()["a"]; v != 2 {
+			return fmt.Errorf("want 2, got %d", v)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if v := clone.Get()["a"]; v != 1 {
+		t.Fatalf("want 1, got %d", v)
+	}
+
+	// 隔离：子协程按 InitFunc 初始化
+	isolated := Holder[int]{Inherit: InheritIsolated, InitFunc: func() int { return -1 }}
+	isolated.Set(1)
+	defer isolated.Del()
+	var got int
+	g.Go(func() error {
+		got = isolated.Get()
+		return nil
+	})
+	g.Wait()
+	if got != -1 {
+		t.Fatalf("want -1, got %d", got)
+	}
+}
+
+// 测试：InheritClone 未设置 CloneFunc 时使用即 panic
+func TestHolderInheritCloneRequiresCloneFunc(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("want panic without CloneFunc")
+		}
+	}()
+	holder := Holder[int]{Inherit: InheritClone}
+	holder.Set(1)
+}
+
+// 测试：Snapshot 和 Restore 在非 Group 启动的协程间传递值
+func TestHolderSnapshot(t *testing.T) {
+	skipWithoutGoid(t)
+	var holder Holder[int]
+	holder.Set(1)
+	defer holder.Del()
+	snap := holder.Snapshot()
+
+	done := make(chan int)
+	go func() {
+		holder.Set(2)
+		restore := holder.Restore(snap)
+		v := holder.Get()
+		restore()
+		done <- v*10 + holder.Get()
+		holder.Del()
+	}()
+	if v := <-done; v != 12 {
+		t.Fatalf("want 12, got %d", v)
+	}
+
+	// 空快照在恢复期间清除当前协程的值
+	var empty Holder[int]
+	snap = empty.Snapshot()
+	empty.Set(3)
+	restore := empty.Restore(snap)
+	if v := empty.Get(); v != 0 {
+		t.Fatalf("want 0, got %d", v)
+	}
+	restore()
+	if v := empty.Del(); v != 3 {
+		t.Fatalf("want 3, got %d", v)
+	}
+}
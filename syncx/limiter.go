@@ -0,0 +1,173 @@
+package syncx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 限制单位时间内的执行次数
+type RateLimiter interface {
+	// 当前可以执行时占用一次并返回true，否则直接返回false
+	Allow() bool
+	// 等待到可以执行，ctx取消时返回ctx的错误
+	Wait(ctx context.Context) error
+}
+
+// 时间来源，测试时可以替换为手动推进的时钟
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+type RateLimiterOption struct {
+	// 每个 Per 时间内允许的次数
+	Rate int
+	Per  time.Duration
+	// 令牌桶的容量，即空闲后允许瞬间执行的次数，小于等于0时等于 Rate，滑动窗口忽略该项
+	Burst int
+	// 为空时使用系统时间
+	Clock Clock
+}
+
+func (opt RateLimiterOption) clock() Clock {
+	if opt.Clock == nil {
+		return systemClock{}
+	}
+	return opt.Clock
+}
+
+// 等待d之后重试，ctx取消时返回ctx的错误
+func waitRetry(ctx context.Context, clock Clock, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-clock.After(d):
+		return nil
+	}
+}
+
+// 令牌桶，按 Rate/Per 的速度补充令牌，最多积累 Burst 个
+type tokenBucket struct {
+	mu       sync.Mutex
+	clock    Clock
+	interval time.Duration // 补充一个令牌的时间
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+func NewTokenBucket(opts RateLimiterOption) RateLimiter {
+	if opts.Rate <= 0 || opts.Per <= 0 {
+		panic("syncx: token bucket requires positive Rate and Per")
+	}
+	burst := opts.Burst
+	if burst <= 0 {
+		burst = opts.Rate
+	}
+	clock := opts.clock()
+	return &tokenBucket{
+		clock:    clock,
+		interval: opts.Per / time.Duration(opts.Rate),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     clock.Now(),
+	}
+}
+
+func (b *tokenBucket) Allow() bool {
+	_, ok := b.take()
+	return ok
+}
+
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		d, ok := b.take()
+		if ok {
+			return nil
+		}
+		if err := waitRetry(ctx, b.clock, d); err != nil {
+			return err
+		}
+	}
+}
+
+// 取一个令牌，没有令牌时返回需要等待的时间
+func (b *tokenBucket) take() (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.clock.Now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += float64(elapsed) / float64(b.interval)
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+	return time.Duration((1 - b.tokens) * float64(b.interval)), false
+}
+
+// 滑动窗口，任意 Per 时间内最多执行 Rate 次
+type slidingWindow struct {
+	mu    sync.Mutex
+	clock Clock
+	per   time.Duration
+	// 窗口内每次执行的时间，环形队列
+	times []time.Time
+	head  int
+	count int
+}
+
+func NewSlidingWindow(opts RateLimiterOption) RateLimiter {
+	if opts.Rate <= 0 || opts.Per <= 0 {
+		panic("syncx: sliding window requires positive Rate and Per")
+	}
+	return &slidingWindow{
+		clock: opts.clock(),
+		per:   opts.Per,
+		times: make([]time.Time, opts.Rate),
+	}
+}
+
+func (w *slidingWindow) Allow() bool {
+	_, ok := w.take()
+	return ok
+}
+
+func (w *slidingWindow) Wait(ctx context.Context) error {
+	for {
+		d, ok := w.take()
+		if ok {
+			return nil
+		}
+		if err := waitRetry(ctx, w.clock, d); err != nil {
+			return err
+		}
+	}
+}
+
+// 记录一次执行，窗口已满时返回最早的记录移出窗口需要等待的时间
+func (w *slidingWindow) take() (time.Duration, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.clock.Now()
+	for w.count > 0 && !w.times[w.head].Add(w.per).After(now) {
+		w.head = (w.head + 1) % len(w.times)
+		w.count--
+	}
+	if w.count == len(w.times) {
+		return w.times[w.head].Add(w.per).Sub(now), false
+	}
+	w.times[(w.head+w.count)%len(w.times)] = now
+	w.count++
+	return 0, true
+}
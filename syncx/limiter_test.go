@@ -0,0 +1,147 @@
+package syncx
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 手动推进的时钟，After 注册后通过 waiting 通知测试
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []fakeTimer
+	waiting chan struct{}
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0), waiting: make(chan struct{}, 16)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), ch: ch})
+	c.waiting <- struct{}{}
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	timers := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			timers = append(timers, t)
+			continue
+		}
+		t.ch <- c.now
+	}
+	c.timers = timers
+}
+
+// 测试：令牌桶允许 Burst 次突发，之后按速度补充
+func TestTokenBucket(t *testing.T) {
+	clock := newFakeClock()
+	l := NewTokenBucket(RateLimiterOption{Rate: 10, Per: time.Second, Burst: 3, Clock: clock})
+	for i := 0; i < 3; i++ {
+		if !l.Allow() {
+			t.Fatalf("want allow %d", i)
+		}
+	}
+	if l.Allow() {
+		t.Fatalf("want throttled")
+	}
+	clock.Advance(100 * time.Millisecond)
+	if !l.Allow() || l.Allow() {
+		t.Fatalf("want exactly one token after 100ms")
+	}
+	// 长时间空闲最多积累 Burst 个
+	clock.Advance(time.Minute)
+	n := 0
+	for l.Allow() {
+		n++
+	}
+	if n != 3 {
+		t.Fatalf("want 3, got %d", n)
+	}
+}
+
+// 测试：Wait 在没有令牌时等待时钟推进
+func TestTokenBucketWait(t *testing.T) {
+	clock := newFakeClock()
+	l := NewTokenBucket(RateLimiterOption{Rate: 1, Per: time.Second, Clock: clock})
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		done <- l.Wait(context.Background())
+	}()
+	<-clock.waiting
+	select {
+	case <-done:
+		t.Fatalf("want waiting")
+	default:
+	}
+	clock.Advance(time.Second)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	// ctx取消时放弃等待
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		done <- l.Wait(ctx)
+	}()
+	<-clock.waiting
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+}
+
+// 测试：滑动窗口内最多 Rate 次，最早的记录移出后才允许
+func TestSlidingWindow(t *testing.T) {
+	clock := newFakeClock()
+	l := NewSlidingWindow(RateLimiterOption{Rate: 2, Per: time.Second, Clock: clock})
+	if !l.Allow() {
+		t.Fatalf("want allow")
+	}
+	clock.Advance(500 * time.Millisecond)
+	if !l.Allow() || l.Allow() {
+		t.Fatalf("want exactly two in window")
+	}
+	clock.Advance(500 * time.Millisecond)
+	if !l.Allow() || l.Allow() {
+		t.Fatalf("want one slot after first expired")
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- l.Wait(context.Background())
+	}()
+	<-clock.waiting
+	clock.Advance(500 * time.Millisecond)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,14 +2,30 @@ package syncx
 
 import (
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // 增强的池，可以设置终结函数，用于对象的回收
+// MaxIdle 大于0时为有界模式，空闲对象由池自己持有，不会被GC回收
 type pool[T any] struct {
 	p sync.Pool
 	PoolOption[T]
-	size     int
-	prepared chan T
+	size   int
+	mu     sync.Mutex
+	idle   []idleItem[T]
+	closed bool
+	now    func() time.Time
+
+	borrowed  atomic.Int64
+	returned  atomic.Int64
+	created   atomic.Int64
+	discarded atomic.Int64
+}
+
+type idleItem[T any] struct {
+	value T
+	since time.Time
 }
 
 type Pool[T any] interface {
@@ -19,15 +35,43 @@ type Pool[T any] interface {
 type PoolOption[T any] struct {
 	Finalizer func(*T)
 	New       func() T
+	// 最多保留的空闲对象数量，大于0时启用有界模式，超出的对象归还时被销毁
+	MaxIdle int
+	// 创建时预先准备的对象数量，仅有界模式有效，不超过 MaxIdle
+	Prewarm int
+	// 借出前检查对象是否可用，不可用的对象被销毁
+	Validate func(*T) bool
+	// 空闲超过该时间的对象被销毁，仅有界模式有效
+	IdleTimeout time.Duration
+	// 销毁对象时调用，例如关闭连接。sync.Pool 丢弃的对象无法回调，因此非有界模式下只在校验失败时调用
+	Destroy func(*T)
+}
+
+// 池的统计信息
+type PoolStats struct {
+	Borrowed  int64 // 借出次数
+	Returned  int64 // 归还次数
+	Created   int64 // 创建的对象数
+	Discarded int64 // 销毁的对象数
+	Idle      int   // 当前空闲对象数，仅有界模式
 }
 
 func (opt PoolOption[T]) Build() *pool[T] {
-	return &pool[T]{
-		p: sync.Pool{New: func() any {
-			return opt.New()
-		}},
+	p := &pool[T]{
 		PoolOption: opt,
+		size:       opt.MaxIdle,
+		now:        time.Now,
 	}
+	if p.size > 0 {
+		n := opt.Prewarm
+		if n > p.size {
+			n = p.size
+		}
+		for i := 0; i < n; i++ {
+			p.idle = append(p.idle, idleItem[T]{value: p.create(), since: p.now()})
+		}
+	}
+	return p
 }
 
 // func NewPool[T any](opts PoolOption[T]) Pool[T] {
@@ -41,7 +85,20 @@ func (opt PoolOption[T]) Build() *pool[T] {
 // }
 
 func (p *pool[T]) Get() (T, func()) {
-	item := p.p.Get().(T)
+	p.borrowed.Add(1)
+	var item T
+	for {
+		var ok bool
+		if item, ok = p.take(); !ok {
+			// 新创建的对象不需要校验
+			item = p.create()
+			break
+		}
+		if p.Validate == nil || p.Validate(&item) {
+			break
+		}
+		p.discard(&item)
+	}
 	ptr := &item
 	return item, func() {
 		p.put(ptr)
@@ -49,8 +106,110 @@ func (p *pool[T]) Get() (T, func()) {
 }
 
 func (p *pool[T]) put(v *T) {
+	p.returned.Add(1)
 	if p.Finalizer != nil {
 		p.Finalizer(v)
 	}
-	p.p.Put(*v)
+	if p.size <= 0 {
+		p.p.Put(*v)
+		return
+	}
+	p.mu.Lock()
+	expired := p.expire()
+	full := p.closed || len(p.idle) >= p.size
+	if !full {
+		p.idle = append(p.idle, idleItem[T]{value: *v, since: p.now()})
+	}
+	p.mu.Unlock()
+	p.discardAll(expired)
+	if full {
+		p.discard(v)
+	}
+}
+
+// 取出最近归还的空闲对象
+func (p *pool[T]) take() (T, bool) {
+	if p.size <= 0 {
+		item, ok := p.p.Get().(T)
+		return item, ok
+	}
+	p.mu.Lock()
+	expired := p.expire()
+	var item T
+	n := len(p.idle)
+	ok := n > 0
+	if ok {
+		item = p.idle[n-1].value
+		p.idle[n-1] = idleItem[T]{}
+		p.idle = p.idle[:n-1]
+	}
+	p.mu.Unlock()
+	p.discardAll(expired)
+	return item, ok
+}
+
+// 移除空闲超时的对象，空闲列表按归还时间排序，超时的对象都在头部
+// 调用方需持有锁
+func (p *pool[T]) expire() []idleItem[T] {
+	if p.IdleTimeout <= 0 || len(p.idle) == 0 {
+		return nil
+	}
+	deadline := p.now().Add(-p.IdleTimeout)
+	n := 0
+	for n < len(p.idle) && p.idle[n].since.Before(deadline) {
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+	expired := make([]idleItem[T], n)
+	copy(expired, p.idle)
+	rest := copy(p.idle, p.idle[n:])
+	for i := rest; i < len(p.idle); i++ {
+		p.idle[i] = idleItem[T]{}
+	}
+	p.idle = p.idle[:rest]
+	return expired
+}
+
+func (p *pool[T]) create() T {
+	p.created.Add(1)
+	return p.New()
+}
+
+func (p *pool[T]) discard(v *T) {
+	p.discarded.Add(1)
+	if p.Destroy != nil {
+		p.Destroy(v)
+	}
+}
+
+func (p *pool[T]) discardAll(items []idleItem[T]) {
+	for i := range items {
+		p.discard(&items[i].value)
+	}
+}
+
+// 统计信息
+func (p *pool[T]) Stats() PoolStats {
+	p.mu.Lock()
+	idle := len(p.idle)
+	p.mu.Unlock()
+	return PoolStats{
+		Borrowed:  p.borrowed.Load(),
+		Returned:  p.returned.Load(),
+		Created:   p.created.Load(),
+		Discarded: p.discarded.Load(),
+		Idle:      idle,
+	}
+}
+
+// 销毁所有空闲对象，之后归还的对象直接销毁，仍然可以借出新创建的对象
+func (p *pool[T]) Close() {
+	p.mu.Lock()
+	items := p.idle
+	p.idle = nil
+	p.closed = true
+	p.mu.Unlock()
+	p.discardAll(items)
 }
@@ -3,6 +3,7 @@ package syncx
 import (
 	"fmt"
 	"testing"
+	"time"
 )
 
 func TestPool(t *testing.T) {
@@ -18,6 +19,143 @@ func TestPool(t *testing.T) {
 	fmt.Println(res)
 }
 
+// 测试：有界模式预先准备对象，超出 MaxIdle 的对象归还时销毁
+func TestPoolBounded(t *testing.T) {
+	var destroyed int
+	pool := PoolOption[*int]{
+		New:     func() *int { return new(int) },
+		MaxIdle: 2,
+		Prewarm: 5,
+		Destroy: func(v **int) { destroyed++ },
+	}.Build()
+	if s := pool.Stats(); s.Created != 2 || s.Idle != 2 {
+		t.Fatalf("want 2 created 2 idle, got %+v", s)
+	}
+
+	var releases []func()
+	for i := 0; i < 3; i++ {
+		_, rec := pool.Get()
+		releases = append(releases, rec)
+	}
+	for _, rec := range releases {
+		rec()
+	}
+	want := PoolStats{Borrowed: 3, Returned: 3, Created: 3, Discarded: 1, Idle: 2}
+	if s := pool.Stats(); s != want {
+		t.Fatalf("want %+v, got %+v", want, s)
+	}
+	if destroyed != 1 {
+		t.Fatalf("want 1, got %d", destroyed)
+	}
+
+	pool.Close()
+	if destroyed != 3 {
+		t.Fatalf("want 3, got %d", destroyed)
+	}
+	_, rec := pool.Get()
+	rec()
+	if destroyed != 4 {
+		t.Fatalf("want 4, got %d", destroyed)
+	}
+}
+
+// 测试：借出时校验失败的对象被销毁，归还时执行 Finalizer
+func TestPoolValidate(t *testing.T) {
+	type conn struct {
+		id     int
+		broken bool
+		closed bool
+	}
+	var next int
+	var closed []*conn
+	pool := PoolOption[*conn]{
+		New: func() *conn {
+			next++
+			return &conn{id: next}
+		},
+		MaxIdle:   4,
+		Validate:  func(c **conn) bool { return !(*c).broken },
+		Finalizer: func(c **conn) { (*c).closed = false },
+		Destroy: func(c **conn) {
+			(*c).closed = true
+			closed = append(closed, *c)
+		},
+	}.Build()
+
+	c, rec := pool.Get()
+	c.broken = true
+	c.closed = true
+	rec()
+	if c.closed {
+		t.Fatalf("want finalizer called")
+	}
+
+	c2, rec2 := pool.Get()
+	defer rec2()
+	if c2.id != 2 {
+		t.Fatalf("want 2, got %d", c2.id)
+	}
+	if len(closed) != 1 || closed[0] != c || !c.closed {
+		t.Fatalf("want conn 1 destroyed, got %v", closed)
+	}
+	if s := pool.Stats(); s.Discarded != 1 || s.Created != 2 {
+		t.Fatalf("want 1 discarded 2 created, got %+v", s)
+	}
+}
+
+// 测试：空闲超时的对象在下次借出或归还时销毁
+func TestPoolIdleTimeout(t *testing.T) {
+	now := time.Unix(0, 0)
+	var destroyed []int
+	var next int
+	pool := PoolOption[int]{
+		New: func() int {
+			next++
+			return next
+		},
+		MaxIdle:     4,
+		IdleTimeout: time.Minute,
+		Destroy:     func(v *int) { destroyed = append(destroyed, *v) },
+	}.Build()
+	pool.now = func() time.Time { return now }
+	_, rec1 := pool.Get()
+	_, rec2 := pool.Get()
+	rec1()
+	rec2()
+	// 对象2在30秒后归还，对象1仍然空闲
+	now = now.Add(30 * time.Second)
+	v, rec := pool.Get()
+	rec()
+	if v != 2 {
+		t.Fatalf("want 2, got %d", v)
+	}
+
+	now = now.Add(45 * time.Second)
+	v, rec = pool.Get()
+	defer rec()
+	if v != 2 {
+		t.Fatalf("want 2, got %d", v)
+	}
+	if len(destroyed) != 1 || destroyed[0] != 1 {
+		t.Fatalf("want [1], got %v", destroyed)
+	}
+	if s := pool.Stats(); s.Idle != 0 {
+		t.Fatalf("want 0, got %d", s.Idle)
+	}
+}
+
+// 测试：非有界模式同样统计借出和创建次数
+func TestPoolStats(t *testing.T) {
+	pool := PoolOption[[]byte]{
+		New: func() []byte { return make([]byte, 0, 8) },
+	}.Build()
+	_, rec := pool.Get()
+	rec()
+	if s := pool.Stats(); s.Borrowed != 1 || s.Returned != 1 || s.Created != 1 {
+		t.Fatalf("want 1 borrowed 1 returned 1 created, got %+v", s)
+	}
+}
+
 func BenchmarkPool(b *testing.B) {
 	var pool = PoolOption[[]string]{
 		New: func() []string {
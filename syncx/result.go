@@ -0,0 +1,145 @@
+package syncx
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// 任务中发生的 panic，携带 panic 的值和当时的调用栈
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("future panic: %v\n%s", e.Value, e.Stack)
+}
+
+func newPanicError(r any) *PanicError {
+	return &PanicError{Value: r, Stack: debug.Stack()}
+}
+
+// 带错误的 Future，任务返回的错误和 panic 都通过 Get / Err 返回
+type Result[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// 在后台执行fn，panic 转为 *PanicError
+func runResult[T any](fn func() (T, error)) *Result[T] {
+	return runResultOn(nil, fn)
+}
+
+// e 为nil时启动新的协程
+func runResultOn[T any](e Executor, fn func() (T, error)) *Result[T] {
+	r := &Result[T]{done: make(chan struct{})}
+	task := func() {
+		defer close(r.done)
+		defer func() {
+			if p := recover(); p != nil {
+				r.err = newPanicError(p)
+			}
+		}()
+		r.value, r.err = fn()
+	}
+	if e == nil {
+		go task()
+	} else if err := e.Submit(task); err != nil {
+		r.err = err
+		close(r.done)
+	}
+	return r
+}
+
+// 等待并返回结果
+func (r *Result[T]) Get() (T, error) {
+	<-r.done
+	return r.value, r.err
+}
+
+// 任务完成后关闭，可用于 select
+func (r *Result[T]) Done() <-chan struct{} {
+	return r.done
+}
+
+// 等待并返回错误
+func (r *Result[T]) Err() error {
+	<-r.done
+	return r.err
+}
+
+// 等待结果，ctx 先结束时返回 ctx 的错误，任务本身不会被中止
+func (r *Result[T]) GetCtx(ctx context.Context) (T, error) {
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// 转为 Future，可以配合 Then / All 等组合函数使用，错误被忽略
+func (r *Result[T]) Future() Future[T] {
+	return func() T {
+		<-r.done
+		return r.value
+	}
+}
+
+// ----------------------------- AsyncResult 系列 -----------------------------
+
+// 异步执行返回 (T, error) 的函数
+func AsyncResult[T any](fn func() (T, error)) *Result[T] {
+	return runResult(fn)
+}
+
+func AsyncResult_0[T any](fn func() (T, error)) func() *Result[T] {
+	return func() *Result[T] {
+		return runResult(func() (T, error) {
+			return fn()
+		})
+	}
+}
+
+func AsyncResult_1[P0, T any](fn func(P0) (T, error)) func(P0) *Result[T] {
+	return func(p0 P0) *Result[T] {
+		return runResult(func() (T, error) {
+			return fn(p0)
+		})
+	}
+}
+
+func AsyncResult_2[P0, P1, T any](fn func(P0, P1) (T, error)) func(P0, P1) *Result[T] {
+	return func(p0 P0, p1 P1) *Result[T] {
+		return runResult(func() (T, error) {
+			return fn(p0, p1)
+		})
+	}
+}
+
+func AsyncResult_3[P0, P1, P2, T any](fn func(P0, P1, P2) (T, error)) func(P0, P1, P2) *Result[T] {
+	return func(p0 P0, p1 P1, p2 P2) *Result[T] {
+		return runResult(func() (T, error) {
+			return fn(p0, p1, p2)
+		})
+	}
+}
+
+func AsyncResult_4[P0, P1, P2, P3, T any](fn func(P0, P1, P2, P3) (T, error)) func(P0, P1, P2, P3) *Result[T] {
+	return func(p0 P0, p1 P1, p2 P2, p3 P3) *Result[T] {
+		return runResult(func() (T, error) {
+			return fn(p0, p1, p2, p3)
+		})
+	}
+}
+
+func AsyncResult_5[P0, P1, P2, P3, P4, T any](fn func(P0, P1, P2, P3, P4) (T, error)) func(P0, P1, P2, P3, P4) *Result[T] {
+	return func(p0 P0, p1 P1, p2 P2, p3 P3, p4 P4) *Result[T] {
+		return runResult(func() (T, error) {
+			return fn(p0, p1, p2, p3, p4)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package syncx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 测试：Result 返回值和错误
+func TestAsyncResult(t *testing.T) {
+	div := AsyncResult_2(func(a, b int) (int, error) {
+		if b == 0 {
+			return 0, errors.New("divide by zero")
+		}
+		return a / b, nil
+	})
+	r := div(6, 3)
+	<-r.Done()
+	if v, err := r.Get(); v != 2 || err != nil {
+		t.Fatalf("want 2, got %v %v", v, err)
+	}
+	if err := div(1, 0).Err(); err == nil || err.Error() != "divide by zero" {
+		t.Fatalf("want divide by zero, got %v", err)
+	}
+}
+
+// 测试：panic 转为带调用栈的 PanicError
+func TestAsyncResultPanic(t *testing.T) {
+	r := AsyncResult(func() (int, error) {
+		var m map[string]int
+		m["a"] = 1
+		return 1, nil
+	})
+	v, err := r.Get()
+	var pe *PanicError
+	if !errors.As(err, &pe) || v != 0 {
+		t.Fatalf("want PanicError, got %v %v", v, err)
+	}
+	if !strings.Contains(string(pe.Stack), "TestAsyncResultPanic") {
+		t.Fatalf("want stack in panic error, got %s", pe.Stack)
+	}
+}
+
+// 测试：GetCtx 在ctx结束时返回
+func TestResultGetCtx(t *testing.T) {
+	r := AsyncResult_0(func() (int, error) {
+		time.Sleep(100 * time.Millisecond)
+		return 1, nil
+	})()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := r.GetCtx(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want DeadlineExceeded, got %v", err)
+	}
+	if v, err := r.GetCtx(context.Background()); v != 1 || err != nil {
+		t.Fatalf("want 1, got %v %v", v, err)
+	}
+	if v := Then(r.Future(), func(v int) int { return v + 1 })(); v != 2 {
+		t.Fatalf("want 2, got %d", v)
+	}
+}
@@ -0,0 +1,111 @@
+package syncx
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// 带权重的信号量，按请求顺序分配，避免大权重的请求一直等不到
+type Semaphore interface {
+	// 获取n个名额，ctx取消时放弃等待并返回ctx的错误
+	Acquire(ctx context.Context, n int64) error
+	// 名额足够且没有排队的请求时获取并返回true，否则直接返回false
+	TryAcquire(n int64) bool
+	// 释放n个名额
+	Release(n int64)
+}
+
+type semaphore struct {
+	mu      sync.Mutex
+	size    int64
+	cur     int64
+	waiters list.List
+}
+
+type semaphoreWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+// 创建总量为n的信号量
+func NewSemaphore(n int64) Semaphore {
+	return &semaphore{size: n}
+}
+
+func (s *semaphore) Acquire(ctx context.Context, n int64) error {
+	s.mu.Lock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+	if n > s.size {
+		// 永远不可能满足，只能等待ctx取消
+		s.mu.Unlock()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	w := semaphoreWaiter{n: n, ready: make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		select {
+		case <-w.ready:
+			// 取消的同时已经分配到名额，归还给后面的请求
+			s.cur -= n
+			s.notify()
+		default:
+			front := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			// 排在最前面的请求放弃后，后面较小的请求可能已经可以满足
+			if front && s.size > s.cur {
+				s.notify()
+			}
+		}
+		return ctx.Err()
+	case <-w.ready:
+		return nil
+	}
+}
+
+func (s *semaphore) TryAcquire(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		return true
+	}
+	return false
+}
+
+func (s *semaphore) Release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cur -= n
+	if s.cur < 0 {
+		panic("syncx: semaphore released more than held")
+	}
+	s.notify()
+}
+
+// 按顺序唤醒可以满足的请求，调用方需持有锁
+func (s *semaphore) notify() {
+	for {
+		next := s.waiters.Front()
+		if next == nil {
+			return
+		}
+		w := next.Value.(semaphoreWaiter)
+		if s.size-s.cur < w.n {
+			return
+		}
+		s.cur += w.n
+		s.waiters.Remove(next)
+		close(w.ready)
+	}
+}
@@ -0,0 +1,71 @@
+package syncx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// 测试：按权重获取和释放名额
+func TestSemaphore(t *testing.T) {
+	s := NewSemaphore(3)
+	if err := s.Acquire(context.Background(), 2); err != nil {
+		t.Fatal(err)
+	}
+	if s.TryAcquire(2) {
+		t.Fatalf("want false")
+	}
+	if !s.TryAcquire(1) {
+		t.Fatalf("want true")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.Acquire(context.Background(), 2)
+		close(done)
+	}()
+	s.Release(1)
+	select {
+	case <-done:
+		t.Fatalf("want waiting")
+	case <-time.After(10 * time.Millisecond):
+	}
+	s.Release(1)
+	<-done
+	s.Release(2)
+	s.Release(1)
+	if !s.TryAcquire(3) {
+		t.Fatalf("want all released")
+	}
+}
+
+// 测试：排队中的请求会阻止后面的小请求插队，ctx取消后让出位置
+func TestSemaphoreCancel(t *testing.T) {
+	s := NewSemaphore(2)
+	s.Acquire(context.Background(), 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error)
+	go func() {
+		errc <- s.Acquire(ctx, 2)
+	}()
+	// 等待大请求进入队列
+	for s.TryAcquire(1) {
+		s.Release(1)
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-errc; err != context.Canceled {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+	if !s.TryAcquire(1) {
+		t.Fatalf("want true")
+	}
+
+	// 超过总量的请求只能等到ctx取消
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Acquire(ctx, 3); err != context.DeadlineExceeded {
+		t.Fatalf("want context.DeadlineExceeded, got %v", err)
+	}
+}
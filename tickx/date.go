@@ -277,7 +277,7 @@ func When(args ...any) bool {
 		return false
 	}
 	right := args[2]
-	cache := compareHolder.Get()
+	cache := compareCache
 	str, isStr := any(left).(string)
 	var leftTime time.Time
 	// 获取左值
@@ -4,21 +4,7 @@ import (
 	"time"
 
 	"github.com/llyb120/yoya/cachex"
-	"github.com/llyb120/yoya/syncx"
 )
 
-var compareHolder syncx.Holder[cachex.Cache[string, time.Time]]
-
-func init() {
-	compareHolder.InitFunc = func() cachex.Cache[string, time.Time] {
-		return cachex.NewBaseCache[string, time.Time](cachex.CacheOption{
-			Expire:           30 * time.Second,
-			CheckInterval:    0,
-			DefaultKeyExpire: 0,
-			Destroy: func() {
-				// 自动清理
-				compareHolder.Del()
-			},
-		})
-	}
-}
+// 比较时的解析缓存，只在 cachex.Scope 内生效，作用域结束自动清理
+var compareCache = cachex.NewScopedCache[string, time.Time](cachex.CacheOption{})